@@ -1,19 +1,569 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
 
+	"github.com/notoriousbfg/football-game/models"
 	"github.com/notoriousbfg/football-game/scenarios"
 	"github.com/notoriousbfg/football-game/simulation"
 )
 
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitInvalidTeam
+)
+
+const usage = `usage: football-game <command> [flags]
+
+commands:
+  simulate   play a single match (the default)
+  batch      play many seeded matches and report the results
+  validate   check team files for mistakes
+  render     draw both line-ups on the pitch
+  train      put a squad through a season of training and report how it improves
+  series     play the same fixture again and again, carrying morale, chemistry and form over
+
+run "football-game <command> -h" for the flags of each command
+`
+
 func main() {
-	sim := simulation.CreateSimulation(
-		scenarios.HomeTeam(),
-		scenarios.AwayTeam(),
-	)
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) (code int) {
+	// the engine panics when a line-up can't field a position it needs;
+	// anything else is a bug and should crash with its stack trace
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok || !errors.Is(err, models.ErrNoPlayer) {
+				panic(r)
+			}
+			fmt.Fprintf(stderr, "simulation failed: %v\n", err)
+			code = exitError
+		}
+	}()
+
+	command := "simulate"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		command, args = args[0], args[1:]
+	}
+
+	var err error
+	switch command {
+	case "simulate":
+		err = simulateCommand(args, stdout)
+	case "batch":
+		err = batchCommand(args, stdout)
+	case "validate":
+		err = validateCommand(args, stdout)
+	case "render":
+		err = renderCommand(args, stdout)
+	case "train":
+		err = trainCommand(args, stdout)
+	case "series":
+		err = seriesCommand(args, stdout)
+	case "help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", command, usage)
+		return exitUsage
+	}
+
+	return exitCode(err, stderr)
+}
+
+func exitCode(err error, stderr io.Writer) int {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	fmt.Fprintln(stderr, err)
+
+	var usageErr usageError
+	var validationErrs models.ValidationErrors
+	var fieldErr models.FieldError
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.As(err, &validationErrs), errors.As(err, &fieldErr):
+		return exitInvalidTeam
+	default:
+		return exitError
+	}
+}
+
+type usageError struct {
+	error
+}
+
+func (u usageError) Unwrap() error {
+	return u.error
+}
+
+// teamFlags are shared by every command that needs a fixture.
+type teamFlags struct {
+	home       string
+	away       string
+	homeTactic string
+	awayTactic string
+}
+
+func (t *teamFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&t.home, "home", "", "home team file (.yaml, .yml or .json); defaults to the built-in home scenario")
+	fs.StringVar(&t.away, "away", "", "away team file (.yaml, .yml or .json); defaults to the built-in away scenario")
+	fs.StringVar(&t.homeTactic, "home-tactic", "", "play the home team with this tactic instead: Counter, Pressing, Defensive or Holding")
+	fs.StringVar(&t.awayTactic, "away-tactic", "", "play the away team with this tactic instead: Counter, Pressing, Defensive or Holding")
+}
+
+func (t *teamFlags) load() (models.Team, models.Team, error) {
+	home, err := loadTeamOr(t.home, scenarios.HomeTeam)
+	if err != nil {
+		return models.Team{}, models.Team{}, err
+	}
+	away, err := loadTeamOr(t.away, scenarios.AwayTeam)
+	if err != nil {
+		return models.Team{}, models.Team{}, err
+	}
+	if home.Name == away.Name {
+		return models.Team{}, models.Team{}, usageError{fmt.Errorf("both teams are called %q", home.Name)}
+	}
+	if err := overrideTactic(&home, t.homeTactic); err != nil {
+		return models.Team{}, models.Team{}, err
+	}
+	if err := overrideTactic(&away, t.awayTactic); err != nil {
+		return models.Team{}, models.Team{}, err
+	}
+	return home, away, nil
+}
+
+func overrideTactic(team *models.Team, tactic string) error {
+	if tactic == "" {
+		return nil
+	}
+	if err := team.Strategy.Tactic.UnmarshalText([]byte(tactic)); err != nil {
+		return usageError{err}
+	}
+	return nil
+}
+
+func loadTeamOr(path string, fallback func() models.Team) (models.Team, error) {
+	if path == "" {
+		return fallback(), nil
+	}
+	return scenarios.LoadTeam(path)
+}
+
+func knockoutRules() simulation.Rules {
+	rules := simulation.DefaultRules()
+	rules.Knockout = true
+	return rules
+}
+
+func loadPlayStyles(path string) (simulation.PlayStyles, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	styles, err := simulation.ParsePlayStyles(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return styles, nil
+}
+
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parseFlags parses a command's flags, printing them for -h, and rejects
+// anything left over.
+func parseFlags(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	if err := parseFlagsWithArgs(fs, args, stdout); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError{fmt.Errorf("unexpected arguments: %v", fs.Args())}
+	}
+	return nil
+}
+
+// parseFlagsWithArgs is parseFlags for commands that take arguments after
+// their flags.
+func parseFlagsWithArgs(fs *flag.FlagSet, args []string, stdout io.Writer) error {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(stdout)
+			fs.PrintDefaults()
+			return err
+		}
+		return usageError{err}
+	}
+	return nil
+}
+
+func simulateCommand(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	var teams teamFlags
+	teams.register(fs)
+	seed := fs.Int64("seed", 0, "seed for the match; picked at random if not given")
+	format := fs.String("format", "text", "output format: text, json (the outcome only) or jsonl (every event)")
+	out := fs.String("out", "", "also write the event log as JSON Lines to this file")
+	quiet := fs.Bool("quiet", false, "only print the final score")
+	knockout := fs.Bool("knockout", false, "settle a draw with extra time and penalties")
+	playStyles := fs.String("play-styles", "", "decision profiles for each play style (.yaml); defaults to the built-in profiles")
+	if err := parseFlags(fs, args, stdout); err != nil {
+		return err
+	}
+
+	home, away, err := teams.load()
+	if err != nil {
+		return err
+	}
+
+	var opts []simulation.Option
+	if isSet(fs, "seed") {
+		opts = append(opts, simulation.WithSeed(*seed))
+	}
+	if *knockout {
+		opts = append(opts, simulation.WithRules(knockoutRules()))
+	}
+	var styles simulation.PlayStyles
+	if *playStyles != "" {
+		if styles, err = loadPlayStyles(*playStyles); err != nil {
+			return err
+		}
+		opts = append(opts, simulation.WithPlayStyles(styles))
+	}
+	for _, team := range []models.Team{home, away} {
+		if err := styles.CheckTeam(team); err != nil {
+			return fmt.Errorf("%s: %w", team.Name, err)
+		}
+	}
+
+	var sinks []simulation.EventSink
+	switch *format {
+	case "text":
+		if !*quiet {
+			sinks = append(sinks, simulation.NewWriterSink(stdout))
+		}
+	case "json":
+	case "jsonl":
+		sinks = append(sinks, simulation.NewJSONLSink(stdout))
+	default:
+		return usageError{fmt.Errorf("unknown format %q", *format)}
+	}
 
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		sinks = append(sinks, simulation.NewJSONLSink(file))
+	}
+
+	opts = append(opts, simulation.WithSinks(sinks...))
+
+	sim := simulation.CreateSimulation(home, away, opts...)
 	sim.Run()
 
-	fmt.Print(sim.State.Outcome)
+	for _, sink := range sinks {
+		if jsonl, ok := sink.(*simulation.JSONLSink); ok && jsonl.Err() != nil {
+			return jsonl.Err()
+		}
+	}
+
+	switch *format {
+	case "text":
+		outcome := sim.State.Outcome
+		fmt.Fprintf(stdout, "%s (seed %d)\n", outcome, outcome.Seed)
+		if !*quiet {
+			fmt.Fprintf(stdout, "xG: %s %.2f - %.2f %s\n", outcome.HomeTeam, outcome.HomeXG, outcome.AwayXG, outcome.AwayTeam)
+		}
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sim.State.Outcome)
+	}
+	return nil
+}
+
+func batchCommand(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	var teams teamFlags
+	teams.register(fs)
+	seed := fs.Int64("seed", 1, "seed of the first match; match n uses seed+n")
+	runs := fs.Int("runs", 1000, "number of matches to play")
+	workers := fs.Int("workers", 0, "matches to play at once; defaults to the number of CPUs")
+	format := fs.String("format", "text", "output format: text or json")
+	top := fs.Int("top", 10, "number of most common scorelines to list in text output")
+	knockout := fs.Bool("knockout", false, "settle draws with extra time and penalties")
+	playStyles := fs.String("play-styles", "", "decision profiles for each play style (.yaml); defaults to the built-in profiles")
+	if err := parseFlags(fs, args, stdout); err != nil {
+		return err
+	}
+	if *runs <= 0 {
+		return usageError{fmt.Errorf("-runs must be positive, got %d", *runs)}
+	}
+	if *format != "text" && *format != "json" {
+		return usageError{fmt.Errorf("unknown format %q", *format)}
+	}
+
+	home, away, err := teams.load()
+	if err != nil {
+		return err
+	}
+
+	var opts []simulation.Option
+	if *knockout {
+		opts = append(opts, simulation.WithRules(knockoutRules()))
+	}
+	var styles simulation.PlayStyles
+	if *playStyles != "" {
+		if styles, err = loadPlayStyles(*playStyles); err != nil {
+			return err
+		}
+		opts = append(opts, simulation.WithPlayStyles(styles))
+	}
+	for _, team := range []models.Team{home, away} {
+		if err := styles.CheckTeam(team); err != nil {
+			return fmt.Errorf("%s: %w", team.Name, err)
+		}
+	}
+
+	report := simulation.RunBatch(
+		simulation.Match{H: home, A: away},
+		simulation.BatchOptions{Runs: *runs, Workers: *workers, Seed: *seed},
+		opts...,
+	)
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	writeBatchReport(stdout, report, *top)
+	return nil
+}
+
+func writeBatchReport(w io.Writer, report simulation.BatchReport, top int) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	fmt.Fprintf(tw, "%s v %s, %d matches from seed %d\n\n", report.HomeTeam, report.AwayTeam, report.Runs, report.Seed)
+
+	fmt.Fprintln(tw, "result\tcount\trate\t95% CI")
+	for _, row := range []struct {
+		name string
+		p    simulation.Proportion
+	}{
+		{report.HomeTeam + " win", report.HomeWins},
+		{"draw", report.Draws},
+		{report.AwayTeam + " win", report.AwayWins},
+		{"on penalties", report.Shootouts},
+	} {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f%%\t%.1f%% - %.1f%%\n", row.name, row.p.Count, row.p.Rate*100, row.p.Low*100, row.p.High*100)
+	}
+
+	fmt.Fprintln(tw, "\ngoals\tmean\t\t95% CI")
+	for _, row := range []struct {
+		name string
+		m    simulation.Mean
+	}{
+		{report.HomeTeam, report.HomeGoals},
+		{report.AwayTeam, report.AwayGoals},
+	} {
+		fmt.Fprintf(tw, "%s\t%.2f\t\t%.2f - %.2f\n", row.name, row.m.Mean, row.m.Low, row.m.High)
+	}
+
+	fmt.Fprintln(tw, "\nxG\tmean\t\t95% CI")
+	for _, row := range []struct {
+		name string
+		m    simulation.Mean
+	}{
+		{report.HomeTeam, report.HomeXG},
+		{report.AwayTeam, report.AwayXG},
+	} {
+		fmt.Fprintf(tw, "%s\t%.2f\t\t%.2f - %.2f\n", row.name, row.m.Mean, row.m.Low, row.m.High)
+	}
+
+	fmt.Fprintln(tw, "\nscoreline\tcount\trate\t")
+	for i, scoreline := range report.Scorelines {
+		if i == top {
+			break
+		}
+		fmt.Fprintf(tw, "%d - %d\t%d\t%.1f%%\t\n", scoreline.HomeScore, scoreline.AwayScore, scoreline.Count, scoreline.Rate*100)
+	}
+}
+
+func validateCommand(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	playStyles := fs.String("play-styles", "", "decision profiles the teams may use as well as the built-in ones (.yaml)")
+	if err := parseFlagsWithArgs(fs, args, stdout); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageError{errors.New("validate needs at least one team file")}
+	}
+
+	var styles simulation.PlayStyles
+	if *playStyles != "" {
+		var err error
+		if styles, err = loadPlayStyles(*playStyles); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, path := range fs.Args() {
+		team, err := scenarios.LoadTeam(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := styles.CheckTeam(team); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: ok\n", path)
+		for _, warning := range team.Warnings() {
+			fmt.Fprintf(stdout, "%s: warning: %s\n", path, warning)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func renderCommand(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	var teams teamFlags
+	teams.register(fs)
+	if err := parseFlags(fs, args, stdout); err != nil {
+		return err
+	}
+
+	home, away, err := teams.load()
+	if err != nil {
+		return err
+	}
+
+	simulation.NewPitch(&simulation.Match{H: home, A: away}).Draw(stdout)
+	return nil
+}
+
+func trainCommand(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("train", flag.ContinueOnError)
+	team := fs.String("team", "", "team file (.yaml, .yml or .json); defaults to the built-in home scenario")
+	weeks := fs.Int("weeks", 38, "number of weeks to train for")
+	seed := fs.Int64("seed", 1, "seed for the training run")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := parseFlags(fs, args, stdout); err != nil {
+		return err
+	}
+	if *weeks <= 0 {
+		return usageError{fmt.Errorf("-weeks must be positive, got %d", *weeks)}
+	}
+	if *format != "text" && *format != "json" {
+		return usageError{fmt.Errorf("unknown format %q", *format)}
+	}
+
+	squad, err := loadTeamOr(*team, scenarios.HomeTeam)
+	if err != nil {
+		return err
+	}
+
+	_, report := simulation.Develop(squad, *weeks, *seed)
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	writeTrainingReport(stdout, report)
+	return nil
+}
+
+func writeTrainingReport(w io.Writer, report simulation.TrainingReport) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	fmt.Fprintf(tw, "%s: %d weeks of %s training (seed %d)\n\n", report.Team, report.Weeks, report.Focus, report.Seed)
+	fmt.Fprintln(tw, "player\tattribute\tbefore\tafter\tchange")
+	improved := 0
+	for _, player := range report.Players {
+		for _, change := range player.Changes {
+			if change.After == change.Before {
+				continue
+			}
+			fmt.Fprintf(tw, "%d %s\t%s\t%d\t%d\t+%d\n", player.Number, player.Name, change.Attribute, change.Before, change.After, change.After-change.Before)
+			improved++
+		}
+	}
+	if improved == 0 {
+		fmt.Fprintln(tw, "no attributes improved")
+	}
+}
+
+func seriesCommand(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("series", flag.ContinueOnError)
+	var teams teamFlags
+	teams.register(fs)
+	matches := fs.Int("matches", 10, "number of matches to play")
+	seed := fs.Int64("seed", 1, "seed of the first match; match n uses seed+n")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := parseFlags(fs, args, stdout); err != nil {
+		return err
+	}
+	if *matches <= 0 {
+		return usageError{fmt.Errorf("-matches must be positive, got %d", *matches)}
+	}
+	if *format != "text" && *format != "json" {
+		return usageError{fmt.Errorf("unknown format %q", *format)}
+	}
+
+	home, away, err := teams.load()
+	if err != nil {
+		return err
+	}
+
+	_, series := simulation.PlaySeries(simulation.Match{H: home, A: away}, *matches, *seed)
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(series)
+	}
+	writeSeries(stdout, home, away, series)
+	return nil
+}
+
+func writeSeries(w io.Writer, home, away models.Team, series []simulation.SeriesMatch) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	fmt.Fprintf(tw, "match\tscore\t%s morale\tchemistry\t%s morale\tchemistry\n", home.Name, away.Name)
+	fmt.Fprintf(tw, "before\t\t%d\t%d\t%d\t%d\n", home.Morale, home.Chemistry, away.Morale, away.Chemistry)
+	for i, match := range series {
+		outcome := match.Outcome
+		fmt.Fprintf(tw, "%d\t%d - %d\t%d\t%d\t%d\t%d\n", i+1, outcome.HomeScore, outcome.AwayScore,
+			match.HomeMorale, match.HomeChemistry, match.AwayMorale, match.AwayChemistry)
+	}
 }
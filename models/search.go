@@ -1,10 +1,15 @@
 package models
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 )
 
+// ErrNoPlayer is what SearchPlayers panics with when nobody in the team can
+// fill any of the positions asked for.
+var ErrNoPlayer = errors.New("no player found")
+
 func (t *Team) SearchPlayers(options PlayerSearchOptions) Player {
 	type PlayerScore struct {
 		Player Player
@@ -89,10 +94,22 @@ func (t *Team) SearchPlayers(options PlayerSearchOptions) Player {
 	}
 
 	if highest == nil {
-		panic(fmt.Errorf("no player found with options (positions: %+v, exclusions: %+v)", options.Positions, options.Exclusions))
+		panic(fmt.Errorf("%w with options (positions: %+v, exclusions: %+v)", ErrNoPlayer, options.Positions, options.Exclusions))
 	}
 
-	return highest.Player
+	// someone filling a position they don't play is moved into whichever of
+	// the positions they'd fit best, so they only play it as well as they fit
+	player := highest.Player
+	if len(options.Positions) > 0 && !slices.Contains(options.Positions, player.Position) {
+		role := options.Positions[0]
+		for _, position := range options.Positions[1:] {
+			if player.FitAt(position) > player.FitAt(role) {
+				role = position
+			}
+		}
+		player = player.PlayingAt(role)
+	}
+	return player
 }
 
 func (t *Team) RandomPlayerInGroup(team *Team, positions []PlayerPosition, randomFloat func() float64) *Player {
@@ -102,6 +119,10 @@ func (t *Team) RandomPlayerInGroup(team *Team, positions []PlayerPosition, rando
 			players = append(players, player)
 		}
 	}
+	// nobody left in the group after substitutions or a sending off
+	if len(players) == 0 {
+		players = team.Players
+	}
 	randomIndex := int(randomFloat() * float64(len(players)))
 	if randomIndex > 0 {
 		randomIndex -= 1
@@ -109,24 +130,180 @@ func (t *Team) RandomPlayerInGroup(team *Team, positions []PlayerPosition, rando
 	return &players[randomIndex]
 }
 
+// ChooseReceiver picks who a pass goes to: backwards or sideways under
+// pressure, forwards otherwise. Player instructions decide who turns up in
+// each part of the pitch and who the passer looks for first.
 func (t *Team) ChooseReceiver(passingPlayer Player, underPressure, isLongPass bool, randomFloat func() float64) *Player {
-	var receiver *Player
+	var group []PlayerPosition
 	if underPressure {
 		if slices.Contains(Forwards, passingPlayer.Position) {
-			receiver = t.RandomPlayerInGroup(t, Midfielders, randomFloat)
+			group = Midfielders
 		} else {
-			receiver = t.RandomPlayerInGroup(t, Defenders, randomFloat)
+			group = Defenders
 		}
 	} else {
-		if slices.Contains(Defenders, passingPlayer.Position) {
-			if isLongPass {
-				receiver = t.RandomPlayerInGroup(t, Forwards, randomFloat)
-			} else {
-				receiver = t.RandomPlayerInGroup(t, Midfielders, randomFloat)
-			}
+		if slices.Contains(Defenders, passingPlayer.Position) && !isLongPass {
+			group = Midfielders
 		} else {
-			receiver = t.RandomPlayerInGroup(t, Forwards, randomFloat)
+			group = Forwards
+		}
+	}
+
+	var (
+		candidates []Player
+		weights    []float64
+		total      float64
+	)
+	forward := slices.Equal(group, Forwards)
+	for _, player := range t.Players {
+		if player.Number == passingPlayer.Number {
+			continue
+		}
+		instruction, instructed := t.Strategy.PlayerInstructions[player.Number]
+		inGroup := slices.Contains(group, player.Position)
+		weight := 1.0
+		switch instruction.Role {
+		case RoleStayBack:
+			inGroup = inGroup && !forward
+		case RoleGetForward:
+			inGroup = inGroup || forward
+			if forward {
+				weight *= 1.25
+			}
+		case RoleFreeRole:
+			inGroup = true
+			weight *= 1.5
+		}
+		if !inGroup {
+			continue
+		}
+		// long balls look for the flanks, short ones through the middle
+		if instructed && (instruction.Position == PositionWing) == isLongPass {
+			weight *= 1.25
+		}
+		candidates = append(candidates, player)
+		weights = append(weights, weight)
+		total += weight
+	}
+
+	if len(candidates) == 0 {
+		// nobody left in the group after substitutions or a sending off
+		return t.RandomPlayerInGroup(t, group, randomFloat)
+	}
+
+	r := randomFloat() * total
+	for i := range candidates {
+		r -= weights[i]
+		if r < 0 {
+			return &candidates[i]
+		}
+	}
+	return &candidates[len(candidates)-1]
+}
+
+// PositionDistance is how many steps apart two positions are in the
+// SimilarPositions graph, or -1 if one can't be reached from the other.
+func PositionDistance(from, to PlayerPosition) int {
+	if from == to {
+		return 0
+	}
+
+	visited := map[PlayerPosition]bool{from: true}
+	frontier := []PlayerPosition{from}
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []PlayerPosition
+		for _, pos := range frontier {
+			for _, similar := range SimilarPositions[pos] {
+				if similar == to {
+					return depth
+				}
+				if !visited[similar] {
+					visited[similar] = true
+					next = append(next, similar)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return -1
+}
+
+// a player's ability away from their natural position drops by
+// positionStepPenalty for every step away in the SimilarPositions graph, and
+// by secondaryPenalty in a secondary position, for a player of average
+// adaptability. It never drops below minPositionFit.
+const (
+	positionStepPenalty = 0.1
+	secondaryPenalty    = 0.03
+	unreachableSteps    = 4 // e.g. an outfield player in goal
+	minPositionFit      = 0.4
+)
+
+// Natural is where a player would rather play.
+func (p Player) Natural() PlayerPosition {
+	if p.NaturalPosition != nil {
+		return *p.NaturalPosition
+	}
+	return p.Position
+}
+
+// PlayingAt moves a player to another position, remembering where they'd
+// rather be.
+func (p Player) PlayingAt(position PlayerPosition) Player {
+	natural := p.Natural()
+	p.NaturalPosition = &natural
+	p.Position = position
+	return p
+}
+
+// FitAt is how much of their ability a player would bring to a position.
+// Adaptable players lose less of it away from their natural position.
+func (p Player) FitAt(position PlayerPosition) float64 {
+	natural := p.Natural()
+	if position == natural {
+		return 1
+	}
+
+	unease := 1.5 - float64(p.Adaptability)/100
+	if slices.Contains(p.SecondaryPositions, position) {
+		return 1 - secondaryPenalty*unease
+	}
+
+	steps := -1
+	for _, from := range append([]PlayerPosition{natural}, p.SecondaryPositions...) {
+		if distance := PositionDistance(from, position); distance >= 0 && (steps < 0 || distance < steps) {
+			steps = distance
 		}
 	}
-	return receiver
+	if steps < 0 {
+		steps = unreachableSteps
+	}
+	return max(minPositionFit, 1-positionStepPenalty*float64(steps)*unease)
+}
+
+// PositionFit is how much of their ability a player brings to the position
+// they're playing.
+func (p Player) PositionFit() float64 {
+	return p.FitAt(p.Position)
+}
+
+// BestReplacement picks the player from candidates who would be most at home
+// in the given position, preferring better form between equally suited
+// players.
+func BestReplacement(position PlayerPosition, candidates []Player) (Player, bool) {
+	best := -1
+	bestFit := 0.0
+	for i, candidate := range candidates {
+		fit := candidate.FitAt(position)
+		if best < 0 || fit > bestFit ||
+			(fit == bestFit && candidate.Form > candidates[best].Form) {
+			best = i
+			bestFit = fit
+		}
+	}
+	if best < 0 {
+		return Player{}, false
+	}
+	return candidates[best], true
 }
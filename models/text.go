@@ -0,0 +1,87 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The enums below are written to team files by name, without the type
+// prefix, e.g. "tactic: Pressing" rather than "tactic: TacticPressing".
+
+func (t Tactic) MarshalText() ([]byte, error) {
+	return []byte(strings.TrimPrefix(t.String(), "Tactic")), nil
+}
+
+func (t *Tactic) UnmarshalText(text []byte) error {
+	return parseName(t, "Tactic", string(text))
+}
+
+func (f Formation) MarshalText() ([]byte, error) {
+	return []byte(strings.TrimPrefix(f.String(), "Formation")), nil
+}
+
+func (f *Formation) UnmarshalText(text []byte) error {
+	return parseName(f, "Formation", string(text))
+}
+
+func (p PositionInstruction) MarshalText() ([]byte, error) {
+	return []byte(strings.TrimPrefix(p.String(), "Position")), nil
+}
+
+func (p *PositionInstruction) UnmarshalText(text []byte) error {
+	return parseName(p, "Position", string(text))
+}
+
+func (r RoleInstruction) MarshalText() ([]byte, error) {
+	return []byte(strings.TrimPrefix(r.String(), "Role")), nil
+}
+
+func (r *RoleInstruction) UnmarshalText(text []byte) error {
+	return parseName(r, "Role", string(text))
+}
+
+func (f TrainingFocus) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+func (f *TrainingFocus) UnmarshalText(text []byte) error {
+	return parseName(f, "", string(text))
+}
+
+func (p PlayerPosition) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *PlayerPosition) UnmarshalText(text []byte) error {
+	return parseName(p, "", string(text))
+}
+
+type enum interface {
+	~int
+	fmt.Stringer
+}
+
+// parseName matches text against the stringer names of an enum, with or
+// without its prefix and ignoring case.
+func parseName[T enum](v *T, prefix, text string) error {
+	var names []string
+	// stringer names anything past the last constant "Type(n)"
+	for candidate := T(0); !strings.Contains(candidate.String(), "("); candidate++ {
+		name := strings.TrimPrefix(candidate.String(), prefix)
+		if strings.EqualFold(text, name) || strings.EqualFold(text, candidate.String()) {
+			*v = candidate
+			return nil
+		}
+		names = append(names, name)
+	}
+	return &UnknownValueError{Value: text, Expected: names}
+}
+
+type UnknownValueError struct {
+	Value    string
+	Expected []string
+}
+
+func (e *UnknownValueError) Error() string {
+	return fmt.Sprintf("unknown value %q, expected one of %s", e.Value, strings.Join(e.Expected, ", "))
+}
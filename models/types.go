@@ -6,13 +6,14 @@ import (
 )
 
 type Team struct {
-	Name      string
-	Strategy  Strategy
-	Morale    int
-	Fitness   int
-	Chemistry int
-	Players   []Player
-	Training  Training
+	Name      string   `json:"name" yaml:"name"`
+	Strategy  Strategy `json:"strategy" yaml:"strategy"`
+	Morale    int      `json:"morale" yaml:"morale"`
+	Fitness   int      `json:"fitness" yaml:"fitness"`
+	Chemistry int      `json:"chemistry" yaml:"chemistry"`
+	Players   []Player `json:"players" yaml:"players"` // the starting XI, or whoever is on the pitch during a match
+	Bench     []Player `json:"bench" yaml:"bench"`
+	Training  Training `json:"training" yaml:"training"`
 }
 
 type PlayerSearchOptions struct {
@@ -23,12 +24,14 @@ type PlayerSearchOptions struct {
 }
 
 type Strategy struct {
-	Tactic             Tactic
-	Formation          Formation
-	PlayerInstructions map[PlayerNumber]Instruction
-	PlayStyle          PlayStyle
+	Tactic             Tactic                       `json:"tactic" yaml:"tactic"`
+	Formation          Formation                    `json:"formation" yaml:"formation"`
+	PlayerInstructions map[PlayerNumber]Instruction `json:"player_instructions" yaml:"player_instructions"`
+	PlayStyle          PlayStyle                    `json:"play_style" yaml:"play_style"`
+	PenaltyTaker       PlayerNumber                 `json:"penalty_taker" yaml:"penalty_taker"` // falls back to the best penalty taker on the pitch
 }
 
+//go:generate stringer -type=Tactic -output tactic_string.go
 type Tactic int
 
 const (
@@ -38,6 +41,7 @@ const (
 	TacticHolding
 )
 
+//go:generate stringer -type=Formation -output formation_string.go
 type Formation int
 
 const (
@@ -47,9 +51,12 @@ const (
 )
 
 type Instruction struct {
-	Position PositionInstruction
+	Position PositionInstruction `json:"position" yaml:"position"`
+	Role     RoleInstruction     `json:"role,omitempty" yaml:"role,omitempty"`
+	Mark     string              `json:"mark,omitempty" yaml:"mark,omitempty"` // the opponent to man-mark, by name
 }
 
+//go:generate stringer -type=PositionInstruction -output position_instruction_string.go
 type PositionInstruction int
 
 const (
@@ -57,20 +64,34 @@ const (
 	PositionCenter
 )
 
-type PlayStyle int
+//go:generate stringer -type=RoleInstruction -output role_instruction_string.go
+type RoleInstruction int
 
 const (
-	PlayStyleCreative PlayStyle = iota
-	PlayStylePredictable
-	PlayStyleDriven
-	PlayStyleCrossing
-	PlayStyleDefensive
+	RoleDefault    RoleInstruction = iota
+	RoleStayBack                   // holds their position and rarely joins the attack
+	RoleGetForward                 // makes runs beyond the forwards
+	RoleManMark                    // follows the opponent named in Mark
+	RoleFreeRole                   // roams wherever they like
+)
+
+// PlayStyle names a decision profile. The built-in styles are below, but a
+// team can use any style that has a profile.
+type PlayStyle string
+
+const (
+	PlayStyleCreative    PlayStyle = "Creative"
+	PlayStylePredictable PlayStyle = "Predictable"
+	PlayStyleDriven      PlayStyle = "Driven"
+	PlayStyleCrossing    PlayStyle = "Crossing"
+	PlayStyleDefensive   PlayStyle = "Defensive"
 )
 
 type Training struct {
-	Focus TrainingFocus
+	Focus TrainingFocus `json:"focus" yaml:"focus"`
 }
 
+//go:generate stringer -type=TrainingFocus -output training_focus_string.go
 type TrainingFocus int
 
 const (
@@ -82,16 +103,18 @@ const (
 )
 
 type Player struct {
-	Name                 string
-	Position             PlayerPosition
-	Number               PlayerNumber
-	Form                 int
-	Adaptability         int
-	Composure            int
-	Technical            TechnicalSkill
-	TacticalIntelligence TacticalIntelligence
-	Stamina              Stamina
-	Fitness              Fitness
+	Name                 string               `json:"name" yaml:"name"`
+	Position             PlayerPosition       `json:"position" yaml:"position"`                                           // where they're playing
+	NaturalPosition      *PlayerPosition      `json:"natural_position,omitempty" yaml:"natural_position,omitempty"`       // where they'd rather play, if not Position
+	SecondaryPositions   []PlayerPosition     `json:"secondary_positions,omitempty" yaml:"secondary_positions,omitempty"` // where they're happy to play too
+	Number               PlayerNumber         `json:"number" yaml:"number"`
+	Form                 int                  `json:"form" yaml:"form"`
+	Adaptability         int                  `json:"adaptability" yaml:"adaptability"`
+	Composure            int                  `json:"composure" yaml:"composure"`
+	Technical            TechnicalSkill       `json:"technical" yaml:"technical"`
+	TacticalIntelligence TacticalIntelligence `json:"tactical_intelligence" yaml:"tactical_intelligence"`
+	Stamina              Stamina              `json:"stamina" yaml:"stamina"`
+	Fitness              Fitness              `json:"fitness" yaml:"fitness"`
 }
 
 func (p Player) Initials() string {
@@ -232,79 +255,79 @@ var OpponentAdjacents = map[PlayerPosition][]PlayerPosition{
 }
 
 type TechnicalSkill struct {
-	Speed       SpeedSkill
-	Passing     PassingSkill
-	Shooting    ShootingSkill
-	Defending   DefendingSkill
-	Dribbling   DribblingSkill
-	Goalkeeping GoalkeepingSkill
-	FreeKicks   int
-	Penalties   int
+	Speed       SpeedSkill       `json:"speed" yaml:"speed"`
+	Passing     PassingSkill     `json:"passing" yaml:"passing"`
+	Shooting    ShootingSkill    `json:"shooting" yaml:"shooting"`
+	Defending   DefendingSkill   `json:"defending" yaml:"defending"`
+	Dribbling   DribblingSkill   `json:"dribbling" yaml:"dribbling"`
+	Goalkeeping GoalkeepingSkill `json:"goalkeeping" yaml:"goalkeeping"`
+	FreeKicks   int              `json:"free_kicks" yaml:"free_kicks"`
+	Penalties   int              `json:"penalties" yaml:"penalties"`
 }
 
 type SpeedSkill struct {
-	Speed        int
-	Acceleration int
+	Speed        int `json:"speed" yaml:"speed"`
+	Acceleration int `json:"acceleration" yaml:"acceleration"`
 }
 
 type PassingSkill struct {
-	ShortPass   int
-	LongPass    int
-	Cross       int
-	Lob         int
-	ThroughBall int
-	Chip        int
+	ShortPass   int `json:"short_pass" yaml:"short_pass"`
+	LongPass    int `json:"long_pass" yaml:"long_pass"`
+	Cross       int `json:"cross" yaml:"cross"`
+	Lob         int `json:"lob" yaml:"lob"`
+	ThroughBall int `json:"through_ball" yaml:"through_ball"`
+	Chip        int `json:"chip" yaml:"chip"`
 }
 
 type ShootingSkill struct {
-	Power     int
-	Curve     int
-	Finishing int
-	Spin      int
+	Power     int `json:"power" yaml:"power"`
+	Curve     int `json:"curve" yaml:"curve"`
+	Finishing int `json:"finishing" yaml:"finishing"`
+	Spin      int `json:"spin" yaml:"spin"`
 }
 
 type DefendingSkill struct {
-	Jumping       int
-	Interceptions int
-	Heading       HeadingSkill
-	Blocking      int
+	Jumping       int          `json:"jumping" yaml:"jumping"`
+	Interceptions int          `json:"interceptions" yaml:"interceptions"`
+	Heading       HeadingSkill `json:"heading" yaml:"heading"`
+	Blocking      int          `json:"blocking" yaml:"blocking"`
 }
 
 type HeadingSkill struct {
-	Accuracy int
-	Power    int
+	Accuracy int `json:"accuracy" yaml:"accuracy"`
+	Power    int `json:"power" yaml:"power"`
 }
 
 type DribblingSkill struct {
-	SkillMoves int
-	Agility    int
-	Dribbling  int
+	SkillMoves int `json:"skill_moves" yaml:"skill_moves"`
+	Agility    int `json:"agility" yaml:"agility"`
+	Dribbling  int `json:"dribbling" yaml:"dribbling"`
 }
 
 type GoalkeepingSkill struct {
-	Reflexes    int
-	Positioning int
-	Reactions   int
+	Reflexes    int `json:"reflexes" yaml:"reflexes"`
+	Positioning int `json:"positioning" yaml:"positioning"`
+	Reactions   int `json:"reactions" yaml:"reactions"`
 }
 
 type TacticalIntelligence struct {
-	Positioning int
-	Vision      TacticalVision
+	Positioning int            `json:"positioning" yaml:"positioning"`
+	Vision      TacticalVision `json:"vision" yaml:"vision"`
 }
 
 type TacticalVision struct {
-	Passing  int // i.e. when to pass and who to pass to
-	Shooting int // i.e. when to shoot
-	Defence  int // i.e. when to drop back
+	Passing  int `json:"passing" yaml:"passing"`   // i.e. when to pass and who to pass to
+	Shooting int `json:"shooting" yaml:"shooting"` // i.e. when to shoot
+	Defence  int `json:"defence" yaml:"defence"`   // i.e. when to drop back
 }
 
 type Stamina struct {
-	Stamina int
+	Stamina int `json:"stamina" yaml:"stamina"`
 }
 
 type Fitness struct {
-	Strength         int
-	Agility          int
-	InjuryTolerance  int
-	InjuryResistance int
+	Strength         int `json:"strength" yaml:"strength"`
+	Agility          int `json:"agility" yaml:"agility"`
+	InjuryTolerance  int `json:"injury_tolerance" yaml:"injury_tolerance"`
+	InjuryResistance int `json:"injury_resistance" yaml:"injury_resistance"`
 }
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const startingPlayers = 11
+
+// FieldError is a problem with a single field of a team, addressed by the
+// same path used in team files, e.g. "players[3].technical.passing.cross".
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, err := range v {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks that a team can be put out on the pitch: a named squad with
+// eleven starters including a goalkeeper, uniquely numbered across the
+// starters and bench, with every rating between 0 and 100.
+func (t Team) Validate() error {
+	var errs ValidationErrors
+	fail := func(path, format string, args ...any) {
+		errs = append(errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(t.Name) == "" {
+		fail("name", "is required")
+	}
+
+	if len(t.Players) != startingPlayers {
+		fail("players", "needs %d starting players, has %d", startingPlayers, len(t.Players))
+	}
+
+	numbers := make(map[PlayerNumber]string)
+	hasGoalkeeper := false
+	checkPlayer := func(path string, player Player) {
+		if strings.TrimSpace(player.Name) == "" {
+			fail(path+".name", "is required")
+		}
+		if player.Number <= 0 {
+			fail(path+".number", "must be positive")
+		} else if first, taken := numbers[player.Number]; taken {
+			fail(path+".number", "%d is already worn by %s", player.Number, first)
+		} else {
+			numbers[player.Number] = path
+		}
+		checkRatings(reflect.ValueOf(player), path, fail)
+	}
+	for i, player := range t.Players {
+		checkPlayer(fmt.Sprintf("players[%d]", i), player)
+		if player.Position == Goalkeeper {
+			hasGoalkeeper = true
+		}
+	}
+	for i, player := range t.Bench {
+		checkPlayer(fmt.Sprintf("bench[%d]", i), player)
+	}
+	if len(t.Players) > 0 && !hasGoalkeeper {
+		fail("players", "needs a goalkeeper")
+	}
+
+	for _, field := range []struct {
+		name  string
+		value int
+	}{{"morale", t.Morale}, {"fitness", t.Fitness}, {"chemistry", t.Chemistry}} {
+		if field.value < 0 || field.value > 100 {
+			fail(field.name, "must be between 0 and 100, got %d", field.value)
+		}
+	}
+
+	if taker := t.Strategy.PenaltyTaker; taker != 0 {
+		if _, ok := numbers[taker]; !ok {
+			fail("strategy.penalty_taker", "no player wears number %d", taker)
+		}
+	}
+
+	for number, instruction := range t.Strategy.PlayerInstructions {
+		path := fmt.Sprintf("strategy.player_instructions.%d", number)
+		if _, ok := numbers[number]; !ok {
+			fail(path, "no player wears number %d", number)
+		}
+		switch {
+		case instruction.Role == RoleManMark && strings.TrimSpace(instruction.Mark) == "":
+			fail(path+".mark", "is required to man-mark")
+		case instruction.Role != RoleManMark && instruction.Mark != "":
+			fail(path+".mark", "only applies with role ManMark")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// starters playing at less than this share of their ability are flagged by
+// Warnings
+const heavyPositionPenalty = 0.8
+
+// Warnings lists things about a team that are allowed but probably a mistake,
+// such as starting a player far from their natural position.
+func (t Team) Warnings() ValidationErrors {
+	var warnings ValidationErrors
+	for i, player := range t.Players {
+		if fit := player.PositionFit(); fit < heavyPositionPenalty {
+			warnings = append(warnings, FieldError{
+				Path:    fmt.Sprintf("players[%d].position", i),
+				Message: fmt.Sprintf("%s is a %s playing as a %s, at %.0f%% of their ability", player.Name, player.Natural(), player.Position, fit*100),
+			})
+		}
+	}
+	return warnings
+}
+
+// checkRatings walks a player's attributes and fails any plain int field that
+// isn't a rating out of 100.
+func checkRatings(v reflect.Value, path string, fail func(path, format string, args ...any)) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		fieldPath := path + "." + name
+		value := v.Field(i)
+
+		switch {
+		case value.Kind() == reflect.Struct:
+			checkRatings(value, fieldPath, fail)
+		case value.Type() == reflect.TypeOf(0):
+			if rating := value.Int(); rating < 0 || rating > 100 {
+				fail(fieldPath, "must be between 0 and 100, got %d", rating)
+			}
+		}
+	}
+}
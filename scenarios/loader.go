@@ -0,0 +1,155 @@
+package scenarios
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/notoriousbfg/football-game/models"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed teams/*.yaml
+var teamFiles embed.FS
+
+type Format int
+
+const (
+	FormatYAML Format = iota
+	FormatJSON
+)
+
+// FormatFor picks the file format from a team file's extension.
+func FormatFor(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return 0, fmt.Errorf("%s: unsupported team file, expected .yaml, .yml or .json", path)
+	}
+}
+
+// LoadTeam reads and validates a team definition file.
+func LoadTeam(path string) (models.Team, error) {
+	format, err := FormatFor(path)
+	if err != nil {
+		return models.Team{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.Team{}, err
+	}
+	team, err := ParseTeam(data, format)
+	if err != nil {
+		return models.Team{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return team, nil
+}
+
+// ParseTeam decodes a team definition and validates it. Unknown fields are
+// rejected so that typos don't silently leave a rating at zero.
+func ParseTeam(data []byte, format Format) (models.Team, error) {
+	var team models.Team
+	switch format {
+	case FormatYAML:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&team); err != nil {
+			var valueErr *models.UnknownValueError
+			if errors.As(err, &valueErr) {
+				return models.Team{}, locateYAMLValue(data, valueErr)
+			}
+			return models.Team{}, err
+		}
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&team); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				return models.Team{}, models.FieldError{
+					Path:    typeErr.Field,
+					Message: fmt.Sprintf("cannot use %s as %s", typeErr.Value, typeErr.Type),
+				}
+			}
+			return models.Team{}, err
+		}
+	default:
+		return models.Team{}, fmt.Errorf("unknown team file format %d", format)
+	}
+
+	if err := team.Validate(); err != nil {
+		return models.Team{}, err
+	}
+	return team, nil
+}
+
+// locateYAMLValue finds where a value the models couldn't parse appears in
+// the file, since yaml.v3 doesn't say where a TextUnmarshaler failed.
+func locateYAMLValue(data []byte, valueErr *models.UnknownValueError) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return valueErr
+	}
+
+	var find func(node *yaml.Node, path string) (string, int, bool)
+	find = func(node *yaml.Node, path string) (string, int, bool) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				if p, line, ok := find(child, path); ok {
+					return p, line, true
+				}
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				childPath := node.Content[i].Value
+				if path != "" {
+					childPath = path + "." + childPath
+				}
+				if p, line, ok := find(node.Content[i+1], childPath); ok {
+					return p, line, true
+				}
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				if p, line, ok := find(child, fmt.Sprintf("%s[%d]", path, i)); ok {
+					return p, line, true
+				}
+			}
+		case yaml.ScalarNode:
+			if node.Value == valueErr.Value {
+				return path, node.Line, true
+			}
+		}
+		return "", 0, false
+	}
+
+	path, line, ok := find(&root, "")
+	if !ok {
+		return valueErr
+	}
+	return models.FieldError{
+		Path:    fmt.Sprintf("line %d: %s", line, path),
+		Message: valueErr.Error(),
+	}
+}
+
+func mustLoadExample(name string) models.Team {
+	data, err := teamFiles.ReadFile("teams/" + name)
+	if err != nil {
+		panic(err)
+	}
+	team, err := ParseTeam(data, FormatYAML)
+	if err != nil {
+		panic(fmt.Errorf("%s: %w", name, err))
+	}
+	return team
+}
@@ -0,0 +1,192 @@
+package simulation
+
+import (
+	"cmp"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// z-score for a 95% confidence interval
+const confidenceZ = 1.96
+
+type BatchOptions struct {
+	Runs    int
+	Workers int   // defaults to the number of CPUs
+	Seed    int64 // run n is seeded with Seed+n
+}
+
+// Proportion is how often something happened, with a Wilson score interval.
+type Proportion struct {
+	Count int     `json:"count"`
+	Rate  float64 `json:"rate"`
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+}
+
+// Mean is an average with a normal-approximation confidence interval.
+type Mean struct {
+	Mean float64 `json:"mean"`
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+type Scoreline struct {
+	HomeScore int     `json:"home_score"`
+	AwayScore int     `json:"away_score"`
+	Count     int     `json:"count"`
+	Rate      float64 `json:"rate"`
+}
+
+type BatchReport struct {
+	Runs       int         `json:"runs"`
+	Seed       int64       `json:"seed"`
+	HomeTeam   string      `json:"home_team"`
+	AwayTeam   string      `json:"away_team"`
+	HomeWins   Proportion  `json:"home_wins"`
+	Draws      Proportion  `json:"draws"`
+	AwayWins   Proportion  `json:"away_wins"`
+	Shootouts  Proportion  `json:"shootouts"` // wins on penalties are counted as wins too
+	HomeGoals  Mean        `json:"home_goals"`
+	AwayGoals  Mean        `json:"away_goals"`
+	HomeXG     Mean        `json:"home_xg"`
+	AwayXG     Mean        `json:"away_xg"`
+	Scorelines []Scoreline `json:"scorelines"` // most common first
+}
+
+// RunBatch plays the same match many times across a pool of workers. Every
+// run is seeded from opts.Seed, so the report doesn't depend on how many
+// workers were used.
+func RunBatch(match Match, opts BatchOptions, simOpts ...Option) BatchReport {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	outcomes := make([]Outcome, opts.Runs)
+	runs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for run := range runs {
+				runOpts := append([]Option{WithSinks()}, simOpts...)
+				runOpts = append(runOpts, WithSeed(opts.Seed+int64(run)))
+				sim := CreateSimulation(match.H, match.A, runOpts...)
+				sim.Run()
+				outcomes[run] = *sim.State.Outcome
+			}
+		}()
+	}
+	for run := range opts.Runs {
+		runs <- run
+	}
+	close(runs)
+	wg.Wait()
+
+	return summarise(match, opts, outcomes)
+}
+
+func summarise(match Match, opts BatchOptions, outcomes []Outcome) BatchReport {
+	var homeWins, draws, awayWins, shootouts int
+	homeGoals := make([]float64, len(outcomes))
+	awayGoals := make([]float64, len(outcomes))
+	homeXG := make([]float64, len(outcomes))
+	awayXG := make([]float64, len(outcomes))
+	scorelines := make(map[[2]int]int)
+	for i, outcome := range outcomes {
+		home, away := outcome.HomeScore, outcome.AwayScore
+		if outcome.Shootout != nil {
+			shootouts++
+			home, away = outcome.Shootout.HomeScore, outcome.Shootout.AwayScore
+		}
+		switch {
+		case home > away:
+			homeWins++
+		case home < away:
+			awayWins++
+		default:
+			draws++
+		}
+		homeGoals[i] = float64(outcome.HomeScore)
+		awayGoals[i] = float64(outcome.AwayScore)
+		homeXG[i] = outcome.HomeXG
+		awayXG[i] = outcome.AwayXG
+		scorelines[[2]int{outcome.HomeScore, outcome.AwayScore}]++
+	}
+
+	n := len(outcomes)
+	report := BatchReport{
+		Runs:      n,
+		Seed:      opts.Seed,
+		HomeTeam:  match.H.Name,
+		AwayTeam:  match.A.Name,
+		HomeWins:  proportion(homeWins, n),
+		Draws:     proportion(draws, n),
+		AwayWins:  proportion(awayWins, n),
+		Shootouts: proportion(shootouts, n),
+		HomeGoals: mean(homeGoals),
+		AwayGoals: mean(awayGoals),
+		HomeXG:    mean(homeXG),
+		AwayXG:    mean(awayXG),
+	}
+
+	for score, count := range scorelines {
+		report.Scorelines = append(report.Scorelines, Scoreline{
+			HomeScore: score[0],
+			AwayScore: score[1],
+			Count:     count,
+			Rate:      float64(count) / float64(n),
+		})
+	}
+	slices.SortFunc(report.Scorelines, func(a, b Scoreline) int {
+		return cmp.Or(
+			cmp.Compare(b.Count, a.Count),
+			cmp.Compare(a.HomeScore, b.HomeScore),
+			cmp.Compare(a.AwayScore, b.AwayScore),
+		)
+	})
+
+	return report
+}
+
+func proportion(count, n int) Proportion {
+	if n == 0 {
+		return Proportion{}
+	}
+	p := float64(count) / float64(n)
+	z2 := confidenceZ * confidenceZ
+	denominator := 1 + z2/float64(n)
+	centre := (p + z2/(2*float64(n))) / denominator
+	margin := confidenceZ * math.Sqrt(p*(1-p)/float64(n)+z2/(4*float64(n)*float64(n))) / denominator
+	return Proportion{
+		Count: count,
+		Rate:  p,
+		Low:   math.Max(0, centre-margin),
+		High:  math.Min(1, centre+margin),
+	}
+}
+
+func mean(values []float64) Mean {
+	n := float64(len(values))
+	if n == 0 {
+		return Mean{}
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	avg := sum / n
+	if n < 2 {
+		return Mean{Mean: avg, Low: avg, High: avg}
+	}
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - avg) * (v - avg)
+	}
+	variance /= n - 1
+	margin := confidenceZ * math.Sqrt(variance/n)
+	return Mean{Mean: avg, Low: avg - margin, High: avg + margin}
+}
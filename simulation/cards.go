@@ -0,0 +1,83 @@
+package simulation
+
+import (
+	"slices"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// how much a side's chance of keeping the ball drops for each player it has
+// fewer than the opposition
+const manDownPenalty = 0.06
+
+type Booking struct {
+	Team    string              `json:"team"`
+	Player  string              `json:"player"`
+	Number  models.PlayerNumber `json:"number"`
+	Yellows int                 `json:"yellows"`
+	SentOff bool                `json:"sent_off"`
+}
+
+func (s *SimulationState) booking(team models.Team, player models.Player) *Booking {
+	for i := range s.Bookings {
+		if s.Bookings[i].Team == team.Name && s.Bookings[i].Number == player.Number {
+			return &s.Bookings[i]
+		}
+	}
+	s.Bookings = append(s.Bookings, Booking{
+		Team:   team.Name,
+		Player: player.Name,
+		Number: player.Number,
+	})
+	return &s.Bookings[len(s.Bookings)-1]
+}
+
+// sendOff takes a player off the pitch for the rest of the match without
+// anyone coming on in their place.
+func (s *SimulationState) sendOff(team models.Team, player models.Player) {
+	s.booking(team, player).SentOff = true
+
+	lineup := s.Simulation.team(team)
+	lineup.Players = slices.DeleteFunc(slices.Clone(lineup.Players), func(p models.Player) bool {
+		return p.Number == player.Number
+	})
+
+	if player.Position == models.Goalkeeper {
+		s.replaceGoalkeeper(lineup)
+	}
+}
+
+// replaceGoalkeeper puts someone in goal after the keeper is sent off: the
+// reserve keeper at the cost of an outfield player if possible, otherwise
+// whichever outfield player is the best shot-stopper.
+func (s *SimulationState) replaceGoalkeeper(lineup *models.Team) {
+	reserve := slices.IndexFunc(lineup.Bench, func(p models.Player) bool {
+		return p.Position == models.Goalkeeper
+	})
+	if reserve >= 0 && s.SubstitutionsLeft(*lineup) > 0 {
+		if off, ok := s.tiredestPlayer(*lineup); ok {
+			if err := s.Substitute(*lineup, off.Number, lineup.Bench[reserve].Number); err != nil {
+				panic(err)
+			}
+			return
+		}
+	}
+
+	best := -1
+	for i, player := range lineup.Players {
+		if best < 0 || player.Technical.Goalkeeping.Reflexes > lineup.Players[best].Technical.Goalkeeping.Reflexes {
+			best = i
+		}
+	}
+	if best >= 0 {
+		lineup.Players[best] = lineup.Players[best].PlayingAt(models.Goalkeeper)
+	}
+}
+
+// numbersFactor scales a side's chances by how many players it has on the
+// pitch compared to the opposition.
+func (s *SimulationState) numbersFactor(team models.Team) float64 {
+	own := len(s.Simulation.lineup(team).Players)
+	opposition := len(s.Simulation.opposingTeam(team).Players)
+	return 1 - manDownPenalty*float64(opposition-own)
+}
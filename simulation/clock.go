@@ -0,0 +1,70 @@
+package simulation
+
+import (
+	"container/heap"
+	"time"
+)
+
+const (
+	halfDuration      = 45 * time.Minute
+	extraTimeDuration = 15 * time.Minute
+	maxExtraTime      = 8 * time.Minute // the most a referee adds on to a half
+)
+
+type scheduledEvent struct {
+	At    time.Duration // since kick-off
+	Seq   int
+	Event Event
+}
+
+// EventQueue orders pending events by match time. Events scheduled for the
+// same moment are handled in the order they were scheduled.
+type EventQueue struct {
+	pending scheduledEvents
+	seq     int
+}
+
+func (q *EventQueue) Schedule(at time.Duration, e Event) {
+	heap.Push(&q.pending, scheduledEvent{At: at, Seq: q.seq, Event: e})
+	q.seq++
+}
+
+func (q *EventQueue) Next() (scheduledEvent, bool) {
+	if len(q.pending) == 0 {
+		return scheduledEvent{}, false
+	}
+	return heap.Pop(&q.pending).(scheduledEvent), true
+}
+
+func (q *EventQueue) Clear() {
+	q.pending = q.pending[:0]
+}
+
+func (q *EventQueue) Len() int {
+	return len(q.pending)
+}
+
+type scheduledEvents []scheduledEvent
+
+func (s scheduledEvents) Len() int { return len(s) }
+
+func (s scheduledEvents) Less(i, j int) bool {
+	if s[i].At == s[j].At {
+		return s[i].Seq < s[j].Seq
+	}
+	return s[i].At < s[j].At
+}
+
+func (s scheduledEvents) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *scheduledEvents) Push(x any) {
+	*s = append(*s, x.(scheduledEvent))
+}
+
+func (s *scheduledEvents) Pop() any {
+	old := *s
+	n := len(old)
+	item := old[n-1]
+	*s = old[:n-1]
+	return item
+}
@@ -0,0 +1,218 @@
+package simulation
+
+import (
+	"math"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// The pitch is measured in metres. X runs the length of the pitch from the
+// home goal to the away goal and Y across it from the home team's left
+// touchline, so the home team always attack towards X = pitchLength. Ends
+// aren't swapped at half time.
+const (
+	pitchLength   = 105.0
+	pitchWidth    = 68.0
+	penaltySpot   = 11.0 // from the goal line
+	goalAreaDepth = 5.5
+)
+
+// how the team shape moves with the ball
+const (
+	blockShift       = 0.3  // how far the whole side follows the ball up and down the pitch
+	ballPull         = 0.25 // how far players are drawn across towards the ball
+	possessionShift  = 6.0  // how far a side pushes on when it has the ball
+	duplicateSpacing = 12.0 // between two players in the same role, e.g. a front two
+	dribbleCarry     = 8.0  // how far a successful dribble takes the ball
+)
+
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func (p Point) Distance(q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+var centreSpot = Point{X: pitchLength / 2, Y: pitchWidth / 2}
+
+// rolePositions is where each role lines up when the ball is on the centre
+// spot, seen from the team's own goal with their left touchline at Y = 0.
+var rolePositions = map[models.PlayerPosition]Point{
+	models.Goalkeeper:                 {6, 34},
+	models.RightBack:                  {28, 60},
+	models.RightWingBack:              {38, 62},
+	models.RightCentreBack:            {22, 44},
+	models.LeftCentreBack:             {22, 24},
+	models.LeftBack:                   {28, 8},
+	models.LeftWingBack:               {38, 6},
+	models.CentralDefensiveMidfielder: {38, 34},
+	models.CentralMidfielder:          {48, 34},
+	models.CentralAttackingMidfielder: {62, 34},
+	models.LeftMidfielder:             {52, 10},
+	models.RightMidfielder:            {52, 58},
+	models.LeftWinger:                 {72, 10},
+	models.RightWinger:                {72, 58},
+	models.CentreForward:              {74, 34},
+	models.Striker:                    {80, 34},
+}
+
+// how much higher or deeper each tactic sets the team's block
+var tacticDepth = map[models.Tactic]float64{
+	models.TacticCounter:   -4,
+	models.TacticPressing:  6,
+	models.TacticDefensive: -8,
+	models.TacticHolding:   0,
+}
+
+// frame converts between the pitch and the team's own view of it, in which
+// they attack towards X = pitchLength. It works both ways.
+func (s *SimulationState) frame(team models.Team, p Point) Point {
+	if s.isHome(team) {
+		return p
+	}
+	return Point{X: pitchLength - p.X, Y: pitchWidth - p.Y}
+}
+
+// goalMouth is the middle of the goal the team is attacking.
+func (s *SimulationState) goalMouth(team models.Team) Point {
+	return s.frame(team, Point{X: pitchLength, Y: pitchWidth / 2})
+}
+
+// location is roughly where a player is on the pitch.
+func (s *SimulationState) location(team models.Team, player models.Player) Point {
+	if at, ok := s.Locations[team.Name][player.Number]; ok {
+		return at
+	}
+	return s.frame(team, rolePositions[player.Position])
+}
+
+// ownHalf is whether a point is in the team's own half.
+func (s *SimulationState) ownHalf(team models.Team, p Point) bool {
+	return s.frame(team, p).X < pitchLength/2
+}
+
+// nearestTo is whichever of the team's players is closest to a point.
+func (s *SimulationState) nearestTo(team models.Team, p Point, players []models.Player) *models.Player {
+	var nearest *models.Player
+	best := math.Inf(1)
+	for i := range players {
+		if distance := s.location(team, players[i]).Distance(p); distance < best {
+			best = distance
+			nearest = &players[i]
+		}
+	}
+	return nearest
+}
+
+// passes get harder beyond a comfortable range for the kind of pass
+const (
+	shortPassRange = 15.0
+	longPassRange  = 35.0
+	passDropOff    = 0.01 // per metre beyond the range
+)
+
+// shots get harder with distance from goal, from shotDropOff a metre beyond
+// closeRange
+const (
+	closeRange  = 8.0
+	shotDropOff = 0.02
+)
+
+// passDistance scales a pass's chance of finding its man by how far it has to
+// travel.
+func (s *SimulationState) passDistance(team models.Team, passer, receiver models.Player, comfortable float64) float64 {
+	distance := s.location(team, passer).Distance(s.location(team, receiver))
+	return max(0.5, 1-passDropOff*max(0, distance-comfortable))
+}
+
+// shotDistance is how far a player is from the goal they're shooting at.
+func (s *SimulationState) shotDistance(team models.Team, player models.Player) float64 {
+	return s.location(team, player).Distance(s.goalMouth(team))
+}
+
+// ballFor is where the ball ends up after an event: with the player
+// finishing it for most of open play, or wherever the restart is taken from.
+func (s *SimulationState) ballFor(e Event) Point {
+	switch e.Type {
+	case ETReset:
+		return centreSpot
+	case ETGoal, ETMiss:
+		return s.goalMouth(e.Team)
+	case ETPenalty, ETShootoutKick:
+		return s.frame(e.Team, Point{X: pitchLength - penaltySpot, Y: pitchWidth / 2})
+	case ETCorner:
+		side := 0.0
+		if s.frame(e.Team, s.Ball).Y > pitchWidth/2 {
+			side = pitchWidth
+		}
+		return s.frame(e.Team, Point{X: pitchLength, Y: side})
+	case ETGoalKick:
+		return s.frame(e.Team, Point{X: goalAreaDepth, Y: pitchWidth / 2})
+	case ETThrowIn:
+		side := 0.0
+		if s.Ball.Y > pitchWidth/2 {
+			side = pitchWidth
+		}
+		return Point{X: s.Ball.X, Y: side}
+	case ETDribble:
+		ball := s.frame(e.Team, s.Ball)
+		ball.X = min(pitchLength, ball.X+dribbleCarry)
+		return s.frame(e.Team, ball)
+	case ETFoul, ETYellowCard, ETRedCard, ETInjury, ETSubstitution, ETOffside, ETPossession,
+		ETFreeKickOnGoal, ETFreeKickDefensiveHalf:
+		// play stops, or carries on, where the ball is
+		return s.Ball
+	}
+	if e.FinishingPlayer != nil && e.Team.Name != "" {
+		return s.location(e.Team, *e.FinishingPlayer)
+	}
+	return s.Ball
+}
+
+// arrange lines both teams up around the ball from their formations. The
+// side in possession pushes on and the other drops off, and each team's
+// tactic sets how high its block is. Whoever finished the event has the ball
+// at their feet.
+func (s *SimulationState) arrange(e Event) {
+	locations := make(map[string]map[models.PlayerNumber]Point)
+	for _, team := range []models.Team{s.Simulation.Match.H, s.Simulation.Match.A} {
+		locations[team.Name] = make(map[models.PlayerNumber]Point)
+		ball := s.frame(team, s.Ball)
+		shift := (ball.X-pitchLength/2)*blockShift + tacticDepth[team.Strategy.Tactic]
+		switch e.Team.Name {
+		case team.Name:
+			shift += possessionShift
+		case "":
+		default:
+			shift -= possessionShift / 2
+		}
+
+		roles := make(map[models.PlayerPosition]int)
+		for _, player := range team.Players {
+			roles[player.Position]++
+		}
+		placed := make(map[models.PlayerPosition]int)
+		for _, player := range team.Players {
+			base := rolePositions[player.Position]
+			base.Y += (float64(placed[player.Position]) - float64(roles[player.Position]-1)/2) * duplicateSpacing
+			placed[player.Position]++
+
+			at := Point{X: base.X + shift, Y: base.Y + (ball.Y-base.Y)*ballPull}
+			if player.Position == models.Goalkeeper {
+				at = Point{X: min(goalAreaDepth*3, max(1, base.X+shift*0.2)), Y: base.Y + (ball.Y-base.Y)*ballPull/2}
+			}
+			at.X = min(pitchLength-1, max(1, at.X))
+			at.Y = min(pitchWidth-1, max(1, at.Y))
+			locations[team.Name][player.Number] = s.frame(team, at)
+		}
+	}
+
+	if e.FinishingPlayer != nil {
+		if _, ok := locations[e.Team.Name][e.FinishingPlayer.Number]; ok {
+			locations[e.Team.Name][e.FinishingPlayer.Number] = s.Ball
+		}
+	}
+	s.Locations = locations
+}
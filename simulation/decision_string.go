@@ -14,11 +14,12 @@ func _() {
 	_ = x[DecisionCross-3]
 	_ = x[DecisionDribble-4]
 	_ = x[DecisionShoot-5]
+	_ = x[DecisionThroughBall-6]
 }
 
-const _Decision_name = "NoDecisionDecisionLongPassDecisionShortPassDecisionCrossDecisionDribbleDecisionShoot"
+const _Decision_name = "NoDecisionDecisionLongPassDecisionShortPassDecisionCrossDecisionDribbleDecisionShootDecisionThroughBall"
 
-var _Decision_index = [...]uint8{0, 10, 26, 43, 56, 71, 84}
+var _Decision_index = [...]uint8{0, 10, 26, 43, 56, 71, 84, 103}
 
 func (i Decision) String() string {
 	if i < 0 || i >= Decision(len(_Decision_index)-1) {
@@ -34,11 +34,21 @@ func _() {
 	_ = x[ETEndOfFirstHalfExtraTime-23]
 	_ = x[ETEndOfSecondHalf-24]
 	_ = x[ETEndOfSecondHalfExtraTime-25]
+	_ = x[ETReset-26]
+	_ = x[ETEndOfExtraTimeFirstHalf-27]
+	_ = x[ETEndOfExtraTimeSecondHalf-28]
+	_ = x[ETPenaltyShootout-29]
+	_ = x[ETShootoutKick-30]
+	_ = x[ETCorner-31]
+	_ = x[ETGoalKick-32]
+	_ = x[ETThrowIn-33]
+	_ = x[ETOffside-34]
+	_ = x[ETInjury-35]
 }
 
-const _EventType_name = "ETNoneETHalfTimeExtraTimeAnnouncementETFullTimeExtraTimeAnnouncementETHalfTimeETFullTimeETSubstitutionETPenaltyETFreeKickOnGoalETFreeKickDefensiveHalfETFoulETAdvantageETYellowCardETRedCardETPassETGoalScoringChanceETInterceptionETDribbleETPossessionETSaveETGoalETMissETCrossETEndOfFirstHalfETEndOfFirstHalfExtraTimeETEndOfSecondHalfETEndOfSecondHalfExtraTime"
+const _EventType_name = "ETNoneETHalfTimeExtraTimeAnnouncementETFullTimeExtraTimeAnnouncementETHalfTimeETFullTimeETSubstitutionETPenaltyETFreeKickOnGoalETFreeKickDefensiveHalfETFoulETAdvantageETYellowCardETRedCardETPassETGoalScoringChanceETInterceptionETDribbleETPossessionETSaveETGoalETMissETCrossETEndOfFirstHalfETEndOfFirstHalfExtraTimeETEndOfSecondHalfETEndOfSecondHalfExtraTimeETResetETEndOfExtraTimeFirstHalfETEndOfExtraTimeSecondHalfETPenaltyShootoutETShootoutKickETCornerETGoalKickETThrowInETOffsideETInjury"
 
-var _EventType_index = [...]uint16{0, 6, 37, 68, 78, 88, 102, 111, 127, 150, 156, 167, 179, 188, 194, 213, 227, 236, 248, 254, 260, 266, 273, 289, 314, 331, 357}
+var _EventType_index = [...]uint16{0, 6, 37, 68, 78, 88, 102, 111, 127, 150, 156, 167, 179, 188, 194, 213, 227, 236, 248, 254, 260, 266, 273, 289, 314, 331, 357, 364, 389, 415, 432, 446, 454, 464, 473, 482, 490}
 
 func (i EventType) String() string {
 	if i < 0 || i >= EventType(len(_EventType_index)-1) {
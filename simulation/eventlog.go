@@ -0,0 +1,177 @@
+package simulation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// EventRecord is the JSON Lines representation of an Event. Teams are
+// referred to by name and players by shirt number and name, so a log can only
+// be read back against the Match it was recorded from.
+type EventRecord struct {
+	Clock           string        `json:"clock"`
+	Seconds         int           `json:"seconds"`
+	Period          string        `json:"period"`
+	Type            string        `json:"type"`
+	Team            string        `json:"team,omitempty"`
+	StartingPlayer  *PlayerRecord `json:"starting_player,omitempty"`
+	FinishingPlayer *PlayerRecord `json:"finishing_player,omitempty"`
+	Meta            EventMeta     `json:"meta,omitempty"`
+	Ball            *Point        `json:"ball,omitempty"`
+}
+
+type PlayerRecord struct {
+	Number models.PlayerNumber `json:"number"`
+	Name   string              `json:"name"`
+}
+
+func NewEventRecord(e Event) EventRecord {
+	seconds := int(e.Time.Seconds())
+	record := EventRecord{
+		Clock:   fmt.Sprintf("%02d:%02d", seconds/60, seconds%60),
+		Seconds: seconds,
+		Period:  e.Period.String(),
+		Type:    e.Type.String(),
+		Team:    e.Team.Name,
+		Meta:    e.EventMeta,
+		Ball:    &Point{X: math.Round(e.Ball.X*10) / 10, Y: math.Round(e.Ball.Y*10) / 10},
+	}
+	if e.StartingPlayer != nil {
+		record.StartingPlayer = &PlayerRecord{Number: e.StartingPlayer.Number, Name: e.StartingPlayer.Name}
+	}
+	if e.FinishingPlayer != nil {
+		record.FinishingPlayer = &PlayerRecord{Number: e.FinishingPlayer.Number, Name: e.FinishingPlayer.Name}
+	}
+	return record
+}
+
+// JSONLSink streams every event to W as one JSON object per line. The first
+// write error stops the stream and is returned by Err.
+type JSONLSink struct {
+	enc *json.Encoder
+	err error
+}
+
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{enc: json.NewEncoder(w)}
+}
+
+func (j *JSONLSink) Receive(s *SimulationState, e Event) {
+	if j.err != nil {
+		return
+	}
+	j.err = j.enc.Encode(NewEventRecord(e))
+}
+
+func (j *JSONLSink) Err() error {
+	return j.err
+}
+
+func WriteEventLog(w io.Writer, events []Event) error {
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(NewEventRecord(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadEventLog reconstructs the events written by WriteEventLog or a
+// JSONLSink, resolving team names and shirt numbers against match.
+func ReadEventLog(r io.Reader, match Match) ([]Event, error) {
+	// stringer names anything past the last constant "EventType(n)"
+	eventTypes := make(map[string]EventType)
+	for t := ETNone; !strings.HasPrefix(t.String(), "EventType("); t++ {
+		eventTypes[t.String()] = t
+	}
+	periods := make(map[string]Period)
+	for p := PeriodFirstHalf; !strings.HasPrefix(p.String(), "Period("); p++ {
+		periods[p.String()] = p
+	}
+
+	events := make([]Event, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record EventRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		eventType, ok := eventTypes[record.Type]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown event type %q", line, record.Type)
+		}
+		period, ok := periods[record.Period]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown period %q", line, record.Period)
+		}
+
+		e := Event{
+			Type:      eventType,
+			Time:      time.Duration(record.Seconds) * time.Second,
+			Period:    period,
+			EventMeta: record.Meta,
+		}
+		if record.Ball != nil {
+			e.Ball = *record.Ball
+		}
+
+		if record.Team != "" {
+			switch record.Team {
+			case match.H.Name:
+				e.Team = match.H
+			case match.A.Name:
+				e.Team = match.A
+			default:
+				return nil, fmt.Errorf("line %d: team %q is not playing in this match", line, record.Team)
+			}
+		}
+
+		var err error
+		if e.StartingPlayer, err = findPlayer(match, record.StartingPlayer); err != nil {
+			return nil, fmt.Errorf("line %d: starting player: %w", line, err)
+		}
+		if e.FinishingPlayer, err = findPlayer(match, record.FinishingPlayer); err != nil {
+			return nil, fmt.Errorf("line %d: finishing player: %w", line, err)
+		}
+
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// findPlayer looks in both teams, since an event's players don't always
+// belong to the team the event is credited to (e.g. an interception), and on
+// both benches for anyone who came on.
+func findPlayer(match Match, record *PlayerRecord) (*models.Player, error) {
+	if record == nil {
+		return nil, nil
+	}
+	for _, squad := range [][]models.Player{match.H.Players, match.A.Players, match.H.Bench, match.A.Bench} {
+		for i := range squad {
+			if squad[i].Number == record.Number && squad[i].Name == record.Name {
+				return &squad[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no player %d (%s)", record.Number, record.Name)
+}
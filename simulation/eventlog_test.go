@@ -0,0 +1,37 @@
+package simulation_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/notoriousbfg/football-game/scenarios"
+	"github.com/notoriousbfg/football-game/simulation"
+)
+
+func TestEventLogRoundTrip(t *testing.T) {
+	match := simulation.Match{H: scenarios.HomeTeam(), A: scenarios.AwayTeam()}
+	for seed := int64(1); seed <= 10; seed++ {
+		sim := simulation.CreateSimulation(match.H, match.A, simulation.WithSeed(seed), simulation.WithSinks())
+		sim.Run()
+
+		var written bytes.Buffer
+		if err := simulation.WriteEventLog(&written, sim.State.Events); err != nil {
+			t.Fatalf("seed %d: writing: %v", seed, err)
+		}
+		events, err := simulation.ReadEventLog(bytes.NewReader(written.Bytes()), match)
+		if err != nil {
+			t.Fatalf("seed %d: reading: %v", seed, err)
+		}
+		if len(events) != len(sim.State.Events) {
+			t.Fatalf("seed %d: read %d events, wrote %d", seed, len(events), len(sim.State.Events))
+		}
+
+		var rewritten bytes.Buffer
+		if err := simulation.WriteEventLog(&rewritten, events); err != nil {
+			t.Fatalf("seed %d: rewriting: %v", seed, err)
+		}
+		if !bytes.Equal(written.Bytes(), rewritten.Bytes()) {
+			t.Errorf("seed %d: the log changed after a round trip", seed)
+		}
+	}
+}
@@ -1,6 +1,13 @@
 package simulation
 
-import "github.com/notoriousbfg/football-game/models"
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/notoriousbfg/football-game/models"
+)
 
 //go:generate stringer -type=Interval -output interval_string.go
 type Interval int
@@ -13,14 +20,30 @@ const (
 
 type Event struct {
 	Type            EventType
+	Time            time.Duration // since kick-off, set when the event is handled
+	Period          Period
 	Team            models.Team
 	StartingPlayer  *models.Player
 	FinishingPlayer *models.Player
 	EventMeta       EventMeta
+	Ball            Point                                    // where the ball is after the event, set when it's handled
+	Locations       map[string]map[models.PlayerNumber]Point // by team name, roughly where everyone is
 }
 
 type EventMeta map[string]interface{}
 
+//go:generate stringer -type=Period -output period_string.go
+type Period int
+
+const (
+	PeriodFirstHalf Period = iota
+	PeriodSecondHalf
+	PeriodExtraTimeFirstHalf
+	PeriodExtraTimeSecondHalf
+	PeriodPenaltyShootout
+	PeriodFullTime
+)
+
 type EventTrigger func(e Event, s *SimulationState)
 
 //go:generate stringer -type=EventType -output event_type_string.go
@@ -54,6 +77,15 @@ const (
 	ETEndOfSecondHalf
 	ETEndOfSecondHalfExtraTime
 	ETReset
+	ETEndOfExtraTimeFirstHalf
+	ETEndOfExtraTimeSecondHalf
+	ETPenaltyShootout
+	ETShootoutKick
+	ETCorner
+	ETGoalKick
+	ETThrowIn
+	ETOffside
+	ETInjury
 )
 
 //go:generate stringer -type=Decision -output decision_string.go
@@ -66,20 +98,58 @@ const (
 	DecisionCross
 	DecisionDribble
 	DecisionShoot
+	DecisionThroughBall
 )
 
 type Outcome struct {
-	HomeScore int
-	AwayScore int
+	Seed      int64  `json:"seed"`
+	Seeded    bool   `json:"seeded"` // false if the seed can't replay the match
+	HomeTeam  string `json:"home_team"`
+	AwayTeam  string `json:"away_team"`
+	HomeScore int    `json:"home_score"`
+	AwayScore int    `json:"away_score"`
+
+	HomeYellowCards int       `json:"home_yellow_cards"`
+	AwayYellowCards int       `json:"away_yellow_cards"`
+	HomeRedCards    int       `json:"home_red_cards"`
+	AwayRedCards    int       `json:"away_red_cards"`
+	HomeOffsides    int       `json:"home_offsides"`
+	AwayOffsides    int       `json:"away_offsides"`
+	HomeXG          float64   `json:"home_xg"`
+	AwayXG          float64   `json:"away_xg"`
+	Injuries        []Injury  `json:"injuries"`
+	Bookings        []Booking `json:"bookings"`
+
+	// the scores above include extra time, which only knockout matches have
+	ExtraTime bool           `json:"extra_time"`
+	Shootout  *ShootoutScore `json:"shootout,omitempty"`
+}
+
+type ShootoutScore struct {
+	HomeScore int `json:"home_score"`
+	AwayScore int `json:"away_score"`
+}
+
+func (o Outcome) String() string {
+	score := fmt.Sprintf("%s %d - %d %s", o.HomeTeam, o.HomeScore, o.AwayScore, o.AwayTeam)
+	switch {
+	case o.Shootout != nil:
+		return fmt.Sprintf("%s (aet, %d - %d on penalties)", score, o.Shootout.HomeScore, o.Shootout.AwayScore)
+	case o.ExtraTime:
+		return score + " (aet)"
+	}
+	return score
 }
 
 type WeightedEventSet map[EventType]float64
 
+// The foul model reads ETFoul as how often winning the ball back is a foul,
+// for an average defender, and the cards as how often it's a foul worth one.
 var WeightedGeneralEvents = WeightedEventSet{
 	ETSubstitution:          0.02,
 	ETFoul:                  0.10,
-	ETYellowCard:            0.06,
-	ETRedCard:               0.01,
+	ETYellowCard:            0.022,
+	ETRedCard:               0.0002,
 	ETFreeKickDefensiveHalf: 0.06,
 }
 
@@ -99,17 +169,20 @@ func RandomWeightedEvent(weights WeightedEventSet, randFloat func() float64) Eve
 		return ETNone
 	}
 
+	// walk the event types in a fixed order so a seeded match is repeatable
+	evtTypes := slices.Sorted(maps.Keys(weights))
+
 	// compute the CDF (Cumulative Distribution Function)
-	cdf := make(WeightedEventSet, len(weights))
+	cdf := make([]float64, len(evtTypes))
 	total := 0.0
-	for i, w := range weights {
-		total += w
+	for i, evtType := range evtTypes {
+		total += weights[evtType]
 		cdf[i] = total
 	}
 
 	r := randFloat() * total
-	for evtType := range cdf {
-		if cdf[evtType] >= r {
+	for i, evtType := range evtTypes {
+		if cdf[i] >= r {
 			return evtType
 		}
 	}
@@ -0,0 +1,94 @@
+package simulation
+
+import (
+	"slices"
+	"time"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// energy runs from fullEnergy for a fresh player down to 0
+const (
+	fullEnergy      = 100.0
+	energyPerMinute = 0.45 // just for being on the pitch
+	sprintCost      = 1.5  // running in behind
+	exhaustedSkill  = 0.7  // how much of a player's ability is left at 0 energy
+)
+
+// exertion is the energy it costs the player finishing an event: the
+// dribbler, the presser who won the ball, the receiver of a cross.
+var exertion = map[EventType]float64{
+	ETDribble:      1.5,
+	ETInterception: 1.0,
+	ETFoul:         1.0,
+	ETOffside:      sprintCost,
+	ETCross:        0.5,
+	ETPass:         0.2,
+}
+
+func (s *SimulationState) initEnergy(teams ...models.Team) {
+	s.Energy = make(map[string]map[models.PlayerNumber]float64)
+	for _, team := range teams {
+		s.Energy[team.Name] = make(map[models.PlayerNumber]float64)
+		for _, player := range slices.Concat(team.Players, team.Bench) {
+			s.Energy[team.Name][player.Number] = fullEnergy
+		}
+	}
+}
+
+func (s *SimulationState) energy(team models.Team, player models.Player) float64 {
+	if energy, ok := s.Energy[team.Name][player.Number]; ok {
+		return energy
+	}
+	return fullEnergy
+}
+
+// fatigue scales a player's skills down as their energy runs out.
+func (s *SimulationState) fatigue(team models.Team, player models.Player) float64 {
+	return exhaustedSkill + (1-exhaustedSkill)*s.energy(team, player)/fullEnergy
+}
+
+// condition is what's left of a player's ability as they tire, while they
+// play on injured and when they're out of position.
+func (s *SimulationState) condition(team models.Team, player models.Player) float64 {
+	return s.fatigue(team, player) * s.injuryFactor(team, player) * player.PositionFit()
+}
+
+// tire takes energy from a player. Players with more stamina, in a fitter
+// squad, tire more slowly, and pressing teams tire more quickly.
+func (s *SimulationState) tire(team models.Team, player models.Player, cost float64) {
+	team = s.Simulation.lineup(team)
+	rate := (1.5 - float64(player.Stamina.Stamina)/100) * (1.25 - float64(team.Fitness)/200)
+	rate *= tacticWorkRate[team.Strategy.Tactic]
+	if s.Energy[team.Name] == nil {
+		s.Energy[team.Name] = make(map[models.PlayerNumber]float64)
+	}
+	s.Energy[team.Name][player.Number] = max(0, s.energy(team, player)-cost*rate)
+}
+
+// drainEnergy tires everyone on the pitch for d of play.
+func (s *SimulationState) drainEnergy(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	for _, team := range []models.Team{s.Simulation.Match.H, s.Simulation.Match.A} {
+		for _, player := range team.Players {
+			s.tire(team, player, energyPerMinute*d.Minutes())
+		}
+	}
+}
+
+// exert charges the player finishing an event for the effort involved.
+func (s *SimulationState) exert(e Event) {
+	cost, ok := exertion[e.Type]
+	if !ok || e.FinishingPlayer == nil {
+		return
+	}
+	if throughBall, _ := e.EventMeta["through_ball"].(bool); throughBall {
+		cost = sprintCost
+	}
+	s.tire(e.Team, *e.FinishingPlayer, cost)
+	if s.energy(e.Team, *e.FinishingPlayer) < fatigueInjuryEnergy {
+		s.considerInjury(e.Team, e.FinishingPlayer, fatigueInjuryChance)
+	}
+}
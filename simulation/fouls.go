@@ -0,0 +1,78 @@
+package simulation
+
+import (
+	"slices"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// how much a player already on a yellow holds back from a second one
+const bookedCaution = 0.3
+
+// how much more (or less) often each tactic leads to fouls and cards
+var tacticFoulRate = map[models.Tactic]float64{
+	models.TacticCounter:   1.0,
+	models.TacticPressing:  1.3,
+	models.TacticDefensive: 1.1,
+	models.TacticHolding:   0.9,
+}
+
+// discipline is how cleanly a defender wins the ball, out of 100.
+func discipline(defender models.Player) float64 {
+	return float64(defender.Technical.Defending.Interceptions)*0.4 +
+		float64(defender.Technical.Defending.Blocking)*0.3 +
+		float64(defender.Composure)*0.3
+}
+
+// isFoul decides whether the challenge that won the ball was a foul. Poor
+// tacklers and aggressive tactics give away more, and running at defenders
+// draws more than passing around them.
+func (s *SimulationState) isFoul(defender models.Player, defendingTeam models.Team) bool {
+	chance := WeightedGeneralEvents[ETFoul] * (1.5 - discipline(defender)/100.0)
+	chance *= tacticFoulRate[defendingTeam.Strategy.Tactic]
+
+	if len(s.Events) > 0 && s.LastEvent().Type == ETDribble {
+		chance *= 1.5
+	}
+
+	return s.Simulation.RandomFloat() < chance
+}
+
+// foulSeverity picks whether a foul is let go with a free kick (ETFoul), or
+// earns a yellow or red card. Hot-headed players are booked more often, and
+// booked players less often.
+func (s *SimulationState) foulSeverity(defender models.Player, defendingTeam models.Team) EventType {
+	temper := (1.5 - float64(defender.Composure)/100.0) * tacticFoulRate[defendingTeam.Strategy.Tactic]
+	if slices.ContainsFunc(s.Bookings, func(b Booking) bool {
+		return b.Team == defendingTeam.Name && b.Number == defender.Number && b.Yellows > 0
+	}) {
+		temper *= bookedCaution
+	}
+	return RandomWeightedEvent(WeightedEventSet{
+		ETFoul:       WeightedGeneralEvents[ETFoul],
+		ETYellowCard: WeightedGeneralEvents[ETYellowCard] * temper,
+		ETRedCard:    WeightedGeneralEvents[ETRedCard] * temper,
+	}, s.Simulation.RandomFloat)
+}
+
+// freeKickType depends on where the foul happened, which we only know from
+// the position of the player who was fouled. Some fouls on forwards happen in
+// the box and give away a penalty.
+func (s *SimulationState) freeKickType(fouled *models.Player) EventType {
+	switch {
+	case fouled == nil:
+		return ETFreeKickDefensiveHalf
+	case slices.Contains(models.Forwards, fouled.Position) || fouled.Position == models.CentralAttackingMidfielder:
+		return RandomWeightedEvent(WeightedEventSet{
+			ETPenalty:        WeightedAttackingEvents[ETPenalty],
+			ETFreeKickOnGoal: WeightedAttackingEvents[ETFreeKickOnGoal],
+		}, s.Simulation.RandomFloat)
+	case slices.Contains(models.Defenders, fouled.Position):
+		return ETFreeKickDefensiveHalf
+	default:
+		return RandomWeightedEvent(WeightedEventSet{
+			ETFreeKickOnGoal:        WeightedAttackingEvents[ETFreeKickOnGoal],
+			ETFreeKickDefensiveHalf: WeightedGeneralEvents[ETFreeKickDefensiveHalf],
+		}, s.Simulation.RandomFloat)
+	}
+}
@@ -0,0 +1,134 @@
+package simulation
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// chances of an injury for a player with no injury resistance at all
+const (
+	foulInjuryChance    = 0.08
+	tackleInjuryChance  = 0.01
+	fatigueInjuryChance = 0.02 // per action once a player is running on empty
+)
+
+// players are at risk of a strain once their energy drops below this
+const fatigueInjuryEnergy = 30.0
+
+//go:generate stringer -type=InjurySeverity -output injury_severity_string.go
+type InjurySeverity int
+
+const (
+	InjuryKnock   InjurySeverity = iota // plays on, not at their best
+	InjuryStrain                        // needs to come off
+	InjurySerious                       // needs to come off, out for months
+)
+
+func (i InjurySeverity) MarshalText() ([]byte, error) {
+	return []byte(strings.TrimPrefix(i.String(), "Injury")), nil
+}
+
+// what's left of a player's ability while they play on injured
+var injuryImpairment = map[InjurySeverity]float64{
+	InjuryKnock:   0.9,
+	InjuryStrain:  0.7,
+	InjurySerious: 0.5,
+}
+
+// recovery time in days, from the minimum up to the minimum plus the spread
+var injuryRecovery = map[InjurySeverity][2]int{
+	InjuryKnock:   {0, 3},
+	InjuryStrain:  {7, 21},
+	InjurySerious: {42, 140},
+}
+
+type Injury struct {
+	Team         string              `json:"team"`
+	Player       string              `json:"player"`
+	Number       models.PlayerNumber `json:"number"`
+	Severity     InjurySeverity      `json:"severity"`
+	RecoveryDays int                 `json:"recovery_days"`
+	Replaced     bool                `json:"replaced"`
+}
+
+// considerInjury rolls for an injury to a player. Players with more injury
+// resistance get hurt less often, and those with more tolerance are more
+// likely to shake it off and play on.
+func (s *SimulationState) considerInjury(team models.Team, player *models.Player, chance float64) {
+	if player == nil || s.injury(team, *player) != nil {
+		return
+	}
+	chance *= 1 - float64(player.Fitness.InjuryResistance)/125.0
+	if s.Simulation.RandomFloat() >= chance {
+		return
+	}
+
+	tolerance := float64(player.Fitness.InjuryTolerance) / 100.0
+	severity := InjuryKnock
+	switch r := s.Simulation.RandomFloat(); {
+	case r < 0.1*(1.5-tolerance):
+		severity = InjurySerious
+	case r < 0.4*(1.5-tolerance):
+		severity = InjuryStrain
+	}
+	recovery := injuryRecovery[severity]
+
+	s.handle(Event{
+		Type:            ETInjury,
+		Team:            s.Simulation.lineup(team),
+		StartingPlayer:  player,
+		FinishingPlayer: player,
+		EventMeta: EventMeta{
+			"severity":      severity,
+			"recovery_days": recovery[0] + int(s.Simulation.RandomFloat()*float64(recovery[1])),
+		},
+	})
+}
+
+func (s *SimulationState) injury(team models.Team, player models.Player) *Injury {
+	for i := range s.Injuries {
+		if s.Injuries[i].Team == team.Name && s.Injuries[i].Number == player.Number {
+			return &s.Injuries[i]
+		}
+	}
+	return nil
+}
+
+// injuryFactor is how much of their ability an injured player has left.
+func (s *SimulationState) injuryFactor(team models.Team, player models.Player) float64 {
+	injury := s.injury(team, player)
+	if injury == nil || injury.Replaced {
+		return 1
+	}
+	return injuryImpairment[injury.Severity]
+}
+
+// replaceInjured takes off anyone who's too badly hurt to carry on, if the
+// team has a substitution left and someone on the bench to bring on. It's
+// called whenever the ball goes dead.
+func (s *SimulationState) replaceInjured() {
+	for i := range s.Injuries {
+		injury := &s.Injuries[i]
+		if injury.Replaced || injury.Severity == InjuryKnock {
+			continue
+		}
+		lineup := s.Simulation.team(models.Team{Name: injury.Team})
+		if s.SubstitutionsLeft(*lineup) <= 0 {
+			continue
+		}
+		off := slices.IndexFunc(lineup.Players, func(p models.Player) bool { return p.Number == injury.Number })
+		if off < 0 {
+			continue
+		}
+		on, ok := models.BestReplacement(lineup.Players[off].Position, lineup.Bench)
+		if !ok {
+			continue
+		}
+		injury.Replaced = true
+		if err := s.Substitute(*lineup, injury.Number, on.Number); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package simulation
+
+import (
+	"slices"
+
+	"github.com/notoriousbfg/football-game/helpers"
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// how much harder it is to keep the ball with a man-marker on you
+const markedPenalty = 0.9
+
+func (s *SimulationState) instruction(team models.Team, player models.Player) models.Instruction {
+	return s.Simulation.lineup(team).Strategy.PlayerInstructions[player.Number]
+}
+
+// decisionProfile is a player's team play style, adjusted for their own
+// instructions.
+func (s *SimulationState) decisionProfile(team models.Team, player models.Player) DecisionProfile {
+	profile := s.Simulation.PlayStyles.profile(team.Strategy.PlayStyle)
+	instruction, ok := s.Simulation.lineup(team).Strategy.PlayerInstructions[player.Number]
+	if !ok {
+		return profile
+	}
+
+	if helpers.IsWinger(player.Position) && instruction.Role != models.RoleFreeRole {
+		switch instruction.Position {
+		case models.PositionWing:
+			// stay wide and get crosses in
+			profile.Crossing *= 1.5
+			profile.Dribbling *= 0.9
+		case models.PositionCenter:
+			// cut inside
+			profile.Crossing *= 0.5
+			profile.Shooting *= 1.3
+			profile.Dribbling *= 1.2
+		}
+	}
+
+	switch instruction.Role {
+	case models.RoleStayBack:
+		profile.Shooting *= 0.5
+		profile.Dribbling *= 0.6
+		profile.ThroughBalls *= 0.8
+	case models.RoleGetForward:
+		profile.Shooting *= 1.3
+		profile.Dribbling *= 1.1
+	case models.RoleFreeRole:
+		profile.Shooting *= 1.1
+		profile.Dribbling *= 1.2
+		profile.ThroughBalls *= 1.3
+	}
+	return profile
+}
+
+// makesRuns is whether a player gets in behind for through balls and into
+// the box for crosses.
+func (s *SimulationState) makesRuns(team models.Team, player models.Player) bool {
+	switch s.instruction(team, player).Role {
+	case models.RoleStayBack:
+		return false
+	case models.RoleGetForward, models.RoleFreeRole:
+		return true
+	}
+	return slices.Contains(models.Forwards, player.Position) || helpers.IsAttacker(player.Position)
+}
+
+// how much likelier a cross is to find someone told to get forward
+const getForwardCrossWeight = 1.5
+
+// crossTarget is the teammate a cross is aimed at, picked from whoever is
+// making runs into the box. The nearer the penalty spot and the better in
+// the air, the likelier they are to get on the end of it, and more so if
+// they've been told to get forward.
+func (s *SimulationState) crossTarget(team models.Team, crosser *models.Player) models.Player {
+	outfield := slices.DeleteFunc(slices.Clone(team.Players), func(p models.Player) bool {
+		return p.Number == crosser.Number || p.Position == models.Goalkeeper
+	})
+	runners := slices.DeleteFunc(slices.Clone(outfield), func(p models.Player) bool {
+		return !s.makesRuns(team, p)
+	})
+	if len(runners) == 0 {
+		runners = outfield
+	}
+
+	box := s.frame(team, Point{X: pitchLength - penaltySpot, Y: pitchWidth / 2})
+	weights := make([]float64, len(runners))
+	total := 0.0
+	for i, player := range runners {
+		weights[i] = aerialSkill(player) / (1 + s.location(team, player).Distance(box)/penaltySpot)
+		if s.instruction(team, player).Role == models.RoleGetForward {
+			weights[i] *= getForwardCrossWeight
+		}
+		total += weights[i]
+	}
+	if total > 0 {
+		r := s.Simulation.RandomFloat() * total
+		for i := range runners {
+			r -= weights[i]
+			if r < 0 {
+				return runners[i]
+			}
+		}
+		return runners[len(runners)-1]
+	}
+
+	return team.SearchPlayers(models.PlayerSearchOptions{
+		Positions:  models.Forwards,
+		Exclusions: map[models.PlayerNumber]string{crosser.Number: crosser.Initials()},
+	})
+}
+
+// marker is the opponent told to man-mark a player, if they're on the pitch.
+func (s *SimulationState) marker(team models.Team, player models.Player) *models.Player {
+	opposingTeam := s.Simulation.opposingTeam(team)
+	for i, opponent := range opposingTeam.Players {
+		instruction := opposingTeam.Strategy.PlayerInstructions[opponent.Number]
+		if instruction.Role == models.RoleManMark && instruction.Mark == player.Name {
+			return &opposingTeam.Players[i]
+		}
+	}
+	return nil
+}
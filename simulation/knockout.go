@@ -0,0 +1,122 @@
+package simulation
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+const (
+	shootoutKicks        = 5
+	shootoutKickInterval = time.Minute
+)
+
+// startExtraTime plays two more periods of 15 minutes after a drawn knockout
+// match. There's a fresh coin toss for kick-off and no stoppage time.
+func (s *SimulationState) startExtraTime() {
+	s.ExtraTimeStarted = true
+	s.Period = PeriodExtraTimeFirstHalf
+	s.EventQueue.Clear()
+	s.Time = s.Start.Add(2 * halfDuration)
+
+	s.ExtraTimeKickoffTeam = s.Simulation.Match.H
+	if s.Simulation.RandomFloat() >= 0.5 {
+		s.ExtraTimeKickoffTeam = s.Simulation.Match.A
+	}
+
+	s.scheduleAt(2*halfDuration+extraTimeDuration, Event{Type: ETEndOfExtraTimeFirstHalf})
+	s.CaptureEvent(s.startingEvent(s.ExtraTimeKickoffTeam))
+}
+
+// penaltyShootout takes turns at kicks from the spot, five each and then
+// sudden death, until one team is ahead having had as many kicks as the
+// other or can no longer be caught. Every kick is logged as it's taken.
+func (s *SimulationState) penaltyShootout() {
+	s.ShootoutStarted = true
+	s.Period = PeriodPenaltyShootout
+	s.handle(Event{Type: ETPenaltyShootout})
+
+	first, second := s.Simulation.Match.H, s.Simulation.Match.A
+	if s.Simulation.RandomFloat() >= 0.5 {
+		first, second = second, first
+	}
+	takers := map[string][]models.Player{
+		first.Name:  shootoutOrder(s.Simulation.lineup(first)),
+		second.Name: shootoutOrder(s.Simulation.lineup(second)),
+	}
+	kicks := map[string]int{}
+
+	decided := func() bool {
+		home, away := s.HomeShootoutScore, s.AwayShootoutScore
+		homeKicks := kicks[s.Simulation.Match.H.Name]
+		awayKicks := kicks[s.Simulation.Match.A.Name]
+		if homeKicks < shootoutKicks || awayKicks < shootoutKicks {
+			homeLeft := max(0, shootoutKicks-homeKicks)
+			awayLeft := max(0, shootoutKicks-awayKicks)
+			return home > away+awayLeft || away > home+homeLeft
+		}
+		return homeKicks == awayKicks && home != away
+	}
+
+	for !decided() {
+		for _, team := range []models.Team{first, second} {
+			order := takers[team.Name]
+			taker := order[kicks[team.Name]%len(order)]
+			kicks[team.Name]++
+			s.shootoutKick(s.Simulation.lineup(team), &taker)
+			if decided() {
+				return
+			}
+		}
+	}
+}
+
+func (s *SimulationState) shootoutKick(team models.Team, taker *models.Player) {
+	s.Time = s.Time.Add(shootoutKickInterval)
+
+	kick := s.penalty(team, taker)
+	result := map[EventType]string{ETGoal: "scored", ETSave: "saved", ETMiss: "missed"}[kick.Type]
+	if kick.Type == ETGoal {
+		if s.isHome(team) {
+			s.HomeShootoutScore++
+		} else {
+			s.AwayShootoutScore++
+		}
+	}
+
+	s.handle(Event{
+		Type:            ETShootoutKick,
+		Team:            team,
+		StartingPlayer:  taker,
+		FinishingPlayer: kick.FinishingPlayer,
+		EventMeta:       EventMeta{"result": result},
+	})
+}
+
+// shootoutOrder lines up a team's penalty takers: the designated taker, then
+// the rest by how good they are from the spot, with the goalkeeper last.
+func shootoutOrder(team models.Team) []models.Player {
+	order := slices.Clone(team.Players)
+	slices.SortStableFunc(order, func(a, b models.Player) int {
+		return cmp.Or(
+			compareFirst(a.Number == team.Strategy.PenaltyTaker, b.Number == team.Strategy.PenaltyTaker),
+			compareFirst(a.Position != models.Goalkeeper, b.Position != models.Goalkeeper),
+			cmp.Compare(penaltySkill(b), penaltySkill(a)),
+		)
+	})
+	return order
+}
+
+// compareFirst orders whichever of a and b is true first.
+func compareFirst(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return -1
+	default:
+		return 1
+	}
+}
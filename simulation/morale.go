@@ -0,0 +1,220 @@
+package simulation
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// how much each part of a team's spirit counts towards the synergy between
+// two teammates, at the extremes of each scale
+const (
+	chemistryWeight = 0.06
+	formWeight      = 0.04
+	moraleWeight    = 0.03
+	momentumWeight  = 0.05
+)
+
+// how much a player's composure is steadied, or rattled, by the team's morale
+// and their own form
+const (
+	moraleComposure = 0.1
+	formComposure   = 0.1
+)
+
+// momentum runs from minMomentum to maxMomentum around an even 1, and drifts
+// back towards 1 by settleRate a minute
+const (
+	minMomentum = 0.5
+	maxMomentum = 1.5
+	settleRate  = 0.05
+	goalSwing   = 0.2 // for the scorers; the conceding side's depends on their morale
+)
+
+// changes to a team's morale over a match and after it
+const (
+	goalMorale    = 4.0
+	redCardMorale = 5.0
+	winMorale     = 5.0
+	winChemistry  = 2.0
+	drawChemistry = 1.0
+)
+
+// what a shot that doesn't go in costs the shooter's form
+const shotForm = 1.0
+
+// formChanges is how much an event lifts, or for a booking knocks, the form of
+// the player finishing it.
+var formChanges = map[EventType]float64{
+	ETGoal:         5.0,
+	ETSave:         1.0,
+	ETInterception: 0.3,
+	ETDribble:      0.3,
+	ETPass:         0.1,
+	ETYellowCard:   -2.0,
+	ETRedCard:      -5.0,
+}
+
+func (s *SimulationState) initMorale(teams ...models.Team) {
+	s.Morale = make(map[string]float64)
+	s.Form = make(map[string]map[models.PlayerNumber]float64)
+	for _, team := range teams {
+		s.Morale[team.Name] = float64(team.Morale)
+		s.Form[team.Name] = make(map[models.PlayerNumber]float64)
+		for _, player := range slices.Concat(team.Players, team.Bench) {
+			s.Form[team.Name][player.Number] = float64(player.Form)
+		}
+	}
+}
+
+func (s *SimulationState) morale(team models.Team) float64 {
+	if morale, ok := s.Morale[team.Name]; ok {
+		return morale
+	}
+	return float64(team.Morale)
+}
+
+func (s *SimulationState) form(team models.Team, player models.Player) float64 {
+	if form, ok := s.Form[team.Name][player.Number]; ok {
+		return form
+	}
+	return float64(player.Form)
+}
+
+func (s *SimulationState) momentum(team models.Team) float64 {
+	if s.isHome(team) {
+		return s.HomeMomentum
+	}
+	return s.AwayMomentum
+}
+
+// synergy scales the chance of a pass between two teammates by how well the
+// side is gelling: its chemistry and morale, the form of both players and
+// how the match is going. Simulation.SynergyMultiplier sets how much it all
+// matters, with 0 turning it off.
+func (s *SimulationState) synergy(team models.Team, passer, receiver models.Player) float64 {
+	lineup := s.Simulation.lineup(team)
+	form := (s.form(team, passer) + s.form(team, receiver)) / 2
+	spirit := chemistryWeight*(float64(lineup.Chemistry)-50)/50 +
+		formWeight*(form-50)/50 +
+		moraleWeight*(s.morale(team)-50)/50 +
+		momentumWeight*(s.momentum(team)-1)/(maxMomentum-1)
+	return 1 + spirit*s.Simulation.SynergyMultiplier
+}
+
+// composure is how calm a player is in front of goal or with a man to beat,
+// which goes up and down with the team's morale and their own form.
+func (s *SimulationState) composure(team models.Team, player models.Player) float64 {
+	nerve := moraleComposure*(s.morale(team)-50)/50 + formComposure*(s.form(team, player)-50)/50
+	composure := float64(player.Composure) * (1 + nerve*s.Simulation.SynergyMultiplier)
+	return min(100, max(0, composure))
+}
+
+// reflect updates morale, form and momentum after an event.
+func (s *SimulationState) reflect(e Event) {
+	if change, ok := formChanges[e.Type]; ok && e.FinishingPlayer != nil {
+		s.changeForm(e.Team, *e.FinishingPlayer, change)
+	}
+
+	opposingTeam := s.Simulation.opposingTeam(e.Team)
+	switch e.Type {
+	case ETGoal:
+		s.changeMorale(e.Team, goalMorale)
+		s.changeMorale(opposingTeam, -goalMorale)
+		// resilient sides shrug off conceding
+		s.swing(e.Team, goalSwing)
+		s.swing(opposingTeam, -goalSwing*(1.5-s.morale(opposingTeam)/100))
+	case ETInterception:
+		// whoever lost the ball
+		s.changeForm(opposingTeam, *e.StartingPlayer, -formChanges[ETInterception])
+	case ETSave:
+		s.changeForm(opposingTeam, *e.StartingPlayer, -shotForm)
+	case ETMiss:
+		s.changeForm(e.Team, *e.StartingPlayer, -shotForm)
+	case ETRedCard:
+		s.changeMorale(e.Team, -redCardMorale)
+	}
+}
+
+func (s *SimulationState) changeForm(team models.Team, player models.Player, change float64) {
+	if s.Form[team.Name] == nil {
+		s.Form[team.Name] = make(map[models.PlayerNumber]float64)
+	}
+	s.Form[team.Name][player.Number] = min(100, max(0, s.form(team, player)+change))
+}
+
+func (s *SimulationState) changeMorale(team models.Team, change float64) {
+	s.Morale[team.Name] = min(100, max(0, s.morale(team)+change))
+}
+
+func (s *SimulationState) swing(team models.Team, change float64) {
+	momentum := min(maxMomentum, max(minMomentum, s.momentum(team)+change))
+	if s.isHome(team) {
+		s.HomeMomentum = momentum
+	} else {
+		s.AwayMomentum = momentum
+	}
+}
+
+// settleMomentum lets both sides' momentum drift back to even over d of play.
+func (s *SimulationState) settleMomentum(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	settle := math.Pow(1-settleRate, d.Minutes())
+	s.HomeMomentum = 1 + (s.HomeMomentum-1)*settle
+	s.AwayMomentum = 1 + (s.AwayMomentum-1)*settle
+}
+
+// AfterMatch is the team as it comes out of the match: morale lifted by a
+// win and knocked by a defeat, chemistry built by playing together, and
+// everyone who got on the pitch carrying their form into the next game.
+func (sim *Simulation) AfterMatch(team models.Team) models.Team {
+	s := sim.State
+
+	morale := s.morale(team)
+	chemistry := float64(team.Chemistry)
+	scored, conceded := s.HomeScore, s.AwayScore
+	if !s.isHome(team) {
+		scored, conceded = conceded, scored
+	}
+	switch {
+	case scored > conceded:
+		morale += winMorale
+		chemistry += winChemistry
+	case scored < conceded:
+		morale -= winMorale
+	default:
+		chemistry += drawChemistry
+	}
+	team.Morale = int(math.Round(min(100, max(0, morale))))
+	team.Chemistry = int(min(100, chemistry))
+
+	team.Players = slices.Clone(team.Players)
+	team.Bench = slices.Clone(team.Bench)
+	for _, squad := range [][]models.Player{team.Players, team.Bench} {
+		for i := range squad {
+			// everyone who got on the pitch has used some energy
+			if s.energy(team, squad[i]) < fullEnergy {
+				squad[i].Form = int(math.Round(s.form(team, squad[i])))
+			}
+		}
+	}
+	return team
+}
+
+// rounded keeps the numbers put in EventMeta readable.
+func rounded(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
+
+// withMeta adds details to an event that may already carry some.
+func withMeta(e Event, meta EventMeta) Event {
+	merged := maps.Clone(meta)
+	maps.Copy(merged, e.EventMeta)
+	e.EventMeta = merged
+	return e
+}
@@ -0,0 +1,87 @@
+package simulation
+
+import (
+	"slices"
+
+	"github.com/notoriousbfg/football-game/helpers"
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// how often a through ball is flagged when the runner and the defensive line
+// are evenly matched
+const offsideChance = 0.25
+
+// throughBall plays a runner in behind the defence. Whether it comes off
+// depends on the passer's vision and the runner's pace against how well the
+// back line holds its position, and a well-drilled line catches runners
+// offside more often.
+func (s *SimulationState) throughBall(team models.Team, passer *models.Player) Event {
+	runners := slices.DeleteFunc(slices.Clone(team.Players), func(p models.Player) bool {
+		return p.Number == passer.Number || !s.makesRuns(team, p)
+	})
+	runner := bestBy(runners, func(p models.Player) float64 {
+		return float64(p.Technical.Speed.Speed)*0.6 + float64(p.Technical.Speed.Acceleration)*0.4
+	})
+	if runner == nil {
+		return s.evaluateDecision(team, passer, DecisionShortPass)
+	}
+
+	line := defensiveLine(s.Simulation.opposingTeam(team))
+	timing := float64(runner.TacticalIntelligence.Positioning)
+	if s.Simulation.RandomFloat() < offsideChance*2*helpers.Sigmoid((line-timing)/15) {
+		return Event{
+			Type:            ETOffside,
+			Team:            team,
+			StartingPlayer:  passer,
+			FinishingPlayer: runner,
+		}
+	}
+
+	vision := float64(passer.TacticalIntelligence.Vision.Passing)*0.5 + float64(passer.Technical.Passing.ThroughBall)*0.5
+	pace := float64(runner.Technical.Speed.Speed)
+	successChance := helpers.Sigmoid((vision*0.5+pace*0.5-line)/10) * s.numbersFactor(team) * s.condition(team, *runner)
+	successChance *= s.training(team, models.Passing)
+	synergy := s.synergy(team, *passer, *runner)
+	successChance *= synergy
+	if s.Simulation.RandomFloat() < successChance {
+		return Event{
+			Type:            ETPass,
+			Team:            team,
+			StartingPlayer:  passer,
+			FinishingPlayer: runner,
+			EventMeta:       EventMeta{"through_ball": true, "synergy": rounded(synergy)},
+		}
+	}
+
+	return withMeta(s.turnover(team, passer), EventMeta{"synergy": rounded(synergy)})
+}
+
+// defensiveLine is how well a team's defenders hold their position.
+func defensiveLine(team models.Team) float64 {
+	total, count := 0.0, 0
+	for _, player := range team.Players {
+		if player.Position == models.Goalkeeper || !slices.Contains(models.Defenders, player.Position) {
+			continue
+		}
+		total += float64(player.TacticalIntelligence.Positioning)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+// offsideFreeKick is an indirect free kick to the defending team from where
+// the runner was caught.
+func (s *SimulationState) offsideFreeKick(e Event) Event {
+	defendingTeam := s.Simulation.opposingTeam(e.Team)
+	taker := s.freeKickTaker(defendingTeam, ETFreeKickDefensiveHalf)
+	return Event{
+		Type:            ETFreeKickDefensiveHalf,
+		Team:            defendingTeam,
+		StartingPlayer:  taker,
+		FinishingPlayer: taker,
+		EventMeta:       EventMeta{"indirect": true, "offside": true},
+	}
+}
@@ -0,0 +1,102 @@
+package simulation
+
+import (
+	"math/rand"
+	"time"
+)
+
+type Option func(*config)
+
+type config struct {
+	seed        int64
+	seeded      bool // false once the random source is replaced
+	randomFloat func() float64
+	start       time.Time
+	sinks       []EventSink
+	rules       Rules
+	playStyles  PlayStyles
+	synergy     float64
+}
+
+// WithSeed seeds the random source so the same seed and teams always
+// produce the same match.
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+// WithRandomFloat replaces the seeded random source entirely, e.g. for
+// scripted matches. The match then has no seed to replay it from, so it
+// isn't recorded as seeded.
+func WithRandomFloat(randomFloat func() float64) Option {
+	return func(c *config) {
+		c.randomFloat = randomFloat
+	}
+}
+
+// WithStart sets the wall-clock time the match clock starts from.
+func WithStart(start time.Time) Option {
+	return func(c *config) {
+		c.start = start
+	}
+}
+
+// WithRules plays the match under different competition rules.
+func WithRules(rules Rules) Option {
+	return func(c *config) {
+		c.rules = rules
+	}
+}
+
+// WithPlayStyles replaces the built-in decision profiles for each play style.
+func WithPlayStyles(styles PlayStyles) Option {
+	return func(c *config) {
+		c.playStyles = styles
+	}
+}
+
+// WithSynergy sets how much team morale, chemistry and player form sway a
+// match. 1 is the default and 0 leaves them out altogether.
+func WithSynergy(multiplier float64) Option {
+	return func(c *config) {
+		c.synergy = multiplier
+	}
+}
+
+// WithSink attaches a sink that receives every event in the match. It can be
+// given more than once; without it, commentary is printed to stdout.
+func WithSink(sink EventSink) Option {
+	return func(c *config) {
+		c.sinks = append(c.sinks, sink)
+	}
+}
+
+// WithSinks attaches all of the given sinks. Calling it with none silences
+// the match.
+func WithSinks(sinks ...EventSink) Option {
+	return func(c *config) {
+		c.sinks = append(make([]EventSink, 0, len(sinks)), sinks...)
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		seed:       time.Now().UnixNano(),
+		rules:      DefaultRules(),
+		playStyles: DefaultPlayStyles(),
+		synergy:    1,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.sinks == nil {
+		c.sinks = []EventSink{NewStdoutSink()}
+	}
+	c.seeded = c.randomFloat == nil
+	if c.seeded {
+		randGen := rand.New(rand.NewSource(c.seed))
+		c.randomFloat = func() float64 { return randGen.Float64() }
+	}
+	return c
+}
@@ -0,0 +1,114 @@
+package simulation
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+
+	"github.com/notoriousbfg/football-game/models"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed playstyles.yaml
+var defaultPlayStyles []byte
+
+// DecisionProfile scales how likely a player is to make each decision on the
+// ball. 1 leaves the chance as it is.
+type DecisionProfile struct {
+	Shooting     float64 `json:"shooting" yaml:"shooting"`
+	Crossing     float64 `json:"crossing" yaml:"crossing"`
+	ThroughBalls float64 `json:"through_balls" yaml:"through_balls"`
+	Dribbling    float64 `json:"dribbling" yaml:"dribbling"`
+	LongPassing  float64 `json:"long_passing" yaml:"long_passing"`
+}
+
+// neutralProfile is used for play styles without a profile.
+var neutralProfile = DecisionProfile{
+	Shooting:     1,
+	Crossing:     1,
+	ThroughBalls: 1,
+	Dribbling:    1,
+	LongPassing:  1,
+}
+
+// PlayStyles maps each play style's name to its decision profile, so new
+// styles only need adding to the data.
+type PlayStyles map[string]DecisionProfile
+
+// profileFields is a DecisionProfile as it's written, so that fields left out
+// can play it straight rather than count as 0.
+type profileFields struct {
+	Shooting     *float64 `yaml:"shooting"`
+	Crossing     *float64 `yaml:"crossing"`
+	ThroughBalls *float64 `yaml:"through_balls"`
+	Dribbling    *float64 `yaml:"dribbling"`
+	LongPassing  *float64 `yaml:"long_passing"`
+}
+
+// ParsePlayStyles reads decision profiles keyed by play style name, in the
+// same format as the built-in playstyles.yaml.
+func ParsePlayStyles(data []byte) (PlayStyles, error) {
+	fields := map[string]profileFields{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+
+	styles := PlayStyles{}
+	for name, f := range fields {
+		profile := neutralProfile
+		for _, field := range []struct {
+			value *float64
+			into  *float64
+		}{
+			{f.Shooting, &profile.Shooting},
+			{f.Crossing, &profile.Crossing},
+			{f.ThroughBalls, &profile.ThroughBalls},
+			{f.Dribbling, &profile.Dribbling},
+			{f.LongPassing, &profile.LongPassing},
+		} {
+			if field.value != nil {
+				*field.into = *field.value
+			}
+		}
+		styles[name] = profile
+	}
+	return styles, nil
+}
+
+// DefaultPlayStyles are the built-in decision profiles.
+func DefaultPlayStyles() PlayStyles {
+	styles, err := ParsePlayStyles(defaultPlayStyles)
+	if err != nil {
+		panic(err)
+	}
+	return styles
+}
+
+func (p PlayStyles) profile(style models.PlayStyle) DecisionProfile {
+	if profile, ok := p[string(style)]; ok {
+		return profile
+	}
+	return neutralProfile
+}
+
+// CheckTeam reports a team whose play style has no profile, either among
+// these styles or the built-in ones, which is most likely a typo. A team
+// without a play style plays it straight.
+func (p PlayStyles) CheckTeam(team models.Team) error {
+	style := string(team.Strategy.PlayStyle)
+	if style == "" {
+		return nil
+	}
+	if _, ok := p[style]; ok {
+		return nil
+	}
+	if _, ok := DefaultPlayStyles()[style]; ok {
+		return nil
+	}
+	return models.ValidationErrors{{
+		Path:    "strategy.play_style",
+		Message: fmt.Sprintf("no profile for play style %q", style),
+	}}
+}
@@ -1,15 +1,18 @@
 package simulation
 
 import (
+	"embed"
 	"fmt"
 	"io"
 	"maps"
-	"os"
 	"strings"
 
 	"github.com/notoriousbfg/football-game/models"
 )
 
+//go:embed templates/*.txt
+var templates embed.FS
+
 type Pitch struct {
 	Match      *Match
 	Exclusions map[string]map[models.PlayerNumber]string
@@ -25,11 +28,11 @@ func NewPitch(match *Match) *Pitch {
 	}
 }
 
-func (p *Pitch) Draw() {
+func (p *Pitch) Draw(w io.Writer) {
 	homeTeam := p.drawHomeTeam(p.Match.H)
 	awayTeam := p.drawAwayTeam(p.Match.A)
 
-	p.drawPitch(homeTeam, awayTeam)
+	p.drawPitch(w, homeTeam, awayTeam)
 }
 
 func (p *Pitch) drawHomeTeam(team models.Team) []string {
@@ -130,13 +133,8 @@ func (p *Pitch) drawAwayTeam(team models.Team) []string {
 	return rows
 }
 
-func (p *Pitch) drawPitch(home, away []string) {
-	templ, err := os.Open("./simulation/templates/pitch.txt")
-	if err != nil {
-		panic(err)
-	}
-	defer templ.Close()
-	body, err := io.ReadAll(templ)
+func (p *Pitch) drawPitch(w io.Writer, home, away []string) {
+	body, err := templates.ReadFile("templates/pitch.txt")
 	if err != nil {
 		panic(err)
 	}
@@ -149,17 +147,12 @@ func (p *Pitch) drawPitch(home, away []string) {
 	result = append(result, home...)
 	result = append(result, pitchParts[2])
 	for _, row := range result {
-		fmt.Println(row)
+		fmt.Fprintln(w, row)
 	}
 }
 
 func (p *Pitch) renderRow(team models.Team, templateName string, positions []models.PlayerPosition) string {
-	templ, err := os.Open(fmt.Sprintf("./simulation/templates/%s.txt", templateName))
-	if err != nil {
-		panic(err)
-	}
-	defer templ.Close()
-	body, err := io.ReadAll(templ)
+	body, err := templates.ReadFile(fmt.Sprintf("templates/%s.txt", templateName))
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,117 @@
+package simulation
+
+import (
+	"slices"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// chances of the ball going out of play instead of someone winning it
+const (
+	deflectedShotChance = 0.3  // a wide shot that takes a touch on the way
+	parriedSaveChance   = 0.4  // less for keepers with quick reactions
+	blockedCrossChance  = 0.4  // a failed cross that's blocked behind
+	clearanceOutChance  = 0.3  // a set piece that's headed behind
+	tackleOutChance     = 0.15 // a tackle that sends the ball off the pitch
+)
+
+// missRestart is a goal kick, unless the shot was deflected behind.
+func (s *SimulationState) missRestart(e Event) Event {
+	if s.Simulation.RandomFloat() < deflectedShotChance {
+		return s.cornerKick(e.Team, "miss")
+	}
+	return s.goalKick(s.Simulation.opposingTeam(e.Team))
+}
+
+// saveRestart is a corner if the keeper can only parry the shot behind,
+// otherwise they've held it and can distribute it.
+func (s *SimulationState) saveRestart(e Event) Event {
+	keeper := e.FinishingPlayer
+	parryChance := parriedSaveChance * (1 - float64(keeper.Technical.Goalkeeping.Reactions)/200.0)
+	if s.Simulation.RandomFloat() < parryChance {
+		return s.cornerKick(s.Simulation.opposingTeam(e.Team), "save")
+	}
+	return s.goalKeeperKick(e)
+}
+
+// blockedCross is a failed cross, which often goes behind for a corner.
+func (s *SimulationState) blockedCross(team models.Team, player *models.Player) Event {
+	if s.Simulation.RandomFloat() < blockedCrossChance {
+		return s.cornerKick(team, "cross")
+	}
+	return s.turnover(team, player)
+}
+
+// cornerKick is awarded to the attacking team and taken by their best crosser.
+func (s *SimulationState) cornerKick(team models.Team, from string) Event {
+	team = s.Simulation.lineup(team)
+	taker := bestBy(team.Players, func(p models.Player) float64 {
+		return float64(p.Technical.Passing.Cross)
+	})
+	return Event{
+		Type:            ETCorner,
+		Team:            team,
+		StartingPlayer:  taker,
+		FinishingPlayer: taker,
+		EventMeta:       EventMeta{"from": from},
+	}
+}
+
+func (s *SimulationState) goalKick(team models.Team) Event {
+	team = s.Simulation.lineup(team)
+	keeper := team.SearchPlayers(models.PlayerSearchOptions{
+		Positions: []models.PlayerPosition{models.Goalkeeper},
+	})
+	return Event{
+		Type:            ETGoalKick,
+		Team:            team,
+		StartingPlayer:  &keeper,
+		FinishingPlayer: &keeper,
+	}
+}
+
+// throwIn is taken by whichever outfield player is nearest to where the ball
+// went out.
+func (s *SimulationState) throwIn(team models.Team) Event {
+	team = s.Simulation.lineup(team)
+	outfield := slices.DeleteFunc(slices.Clone(team.Players), func(p models.Player) bool {
+		return p.Position == models.Goalkeeper
+	})
+	out := Point{X: s.Ball.X, Y: 0}
+	if s.Ball.Y > pitchWidth/2 {
+		out.Y = pitchWidth
+	}
+	thrower := s.nearestTo(team, out, outfield)
+	if thrower == nil {
+		thrower = s.nearestTo(team, out, team.Players)
+	}
+	return Event{
+		Type:            ETThrowIn,
+		Team:            team,
+		StartingPlayer:  thrower,
+		FinishingPlayer: thrower,
+	}
+}
+
+// corner goes up for an aerial duel between the best headers in the box.
+func (s *SimulationState) corner(e Event) Event {
+	return s.aerialDelivery(s.Simulation.lineup(e.Team), e.FinishingPlayer, "corner")
+}
+
+// takeThrowIn finds a nearby teammate; it's hard to throw it far.
+func (s *SimulationState) takeThrowIn(e Event) Event {
+	team := s.Simulation.lineup(e.Team)
+	thrower := e.FinishingPlayer
+	receiver := team.ChooseReceiver(*thrower, false, false, s.Simulation.RandomFloat)
+	synergy := s.synergy(team, *thrower, *receiver)
+	if !s.evaluateShortPass(team, *thrower, *receiver, synergy) {
+		return withMeta(s.turnover(team, thrower), EventMeta{"synergy": rounded(synergy)})
+	}
+	return Event{
+		Type:            ETPass,
+		Team:            team,
+		StartingPlayer:  thrower,
+		FinishingPlayer: receiver,
+		EventMeta:       EventMeta{"throw_in": true, "synergy": rounded(synergy)},
+	}
+}
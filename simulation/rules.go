@@ -0,0 +1,15 @@
+package simulation
+
+// Rules are the competition rules a match is played under.
+type Rules struct {
+	MaxSubstitutions int
+	// Knockout matches can't end in a draw: level scores go to extra time
+	// and then a penalty shootout.
+	Knockout bool
+}
+
+func DefaultRules() Rules {
+	return Rules{
+		MaxSubstitutions: 5,
+	}
+}
@@ -0,0 +1,36 @@
+package simulation
+
+// SeriesMatch is one match of a series and the state both teams came out of
+// it in.
+type SeriesMatch struct {
+	Outcome       Outcome `json:"outcome"`
+	HomeMorale    int     `json:"home_morale"`
+	HomeChemistry int     `json:"home_chemistry"`
+	AwayMorale    int     `json:"away_morale"`
+	AwayChemistry int     `json:"away_chemistry"`
+}
+
+// PlaySeries plays the same fixture again and again, carrying morale,
+// chemistry and form from each match into the next. Match n is seeded with
+// seed+n. It returns the teams as they finish the series, leaving the ones
+// passed in alone.
+func PlaySeries(match Match, matches int, seed int64, opts ...Option) (Match, []SeriesMatch) {
+	home, away := match.H, match.A
+	series := make([]SeriesMatch, 0, matches)
+	for n := range matches {
+		matchOpts := append([]Option{WithSinks()}, opts...)
+		matchOpts = append(matchOpts, WithSeed(seed+int64(n)))
+		sim := CreateSimulation(home, away, matchOpts...)
+		sim.Run()
+
+		home, away = sim.AfterMatch(home), sim.AfterMatch(away)
+		series = append(series, SeriesMatch{
+			Outcome:       *sim.State.Outcome,
+			HomeMorale:    home.Morale,
+			HomeChemistry: home.Chemistry,
+			AwayMorale:    away.Morale,
+			AwayChemistry: away.Chemistry,
+		})
+	}
+	return Match{H: home, A: away}, series
+}
@@ -0,0 +1,310 @@
+package simulation
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/notoriousbfg/football-game/helpers"
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// players lining up in a defensive wall
+const wallSize = 4
+
+// how far out a set piece is met, between the six-yard box and the spot
+const headerRange = 9.0
+
+// bestBy is the player with the highest score, ignoring the goalkeeper.
+func bestBy(players []models.Player, score func(models.Player) float64) *models.Player {
+	var best *models.Player
+	for i := range players {
+		if players[i].Position == models.Goalkeeper {
+			continue
+		}
+		if best == nil || score(players[i]) > score(*best) {
+			best = &players[i]
+		}
+	}
+	return best
+}
+
+func freeKickSkill(p models.Player) float64 {
+	return float64(p.Technical.FreeKicks)*0.6 +
+		float64(p.Technical.Shooting.Curve)*0.25 +
+		float64(p.Technical.Shooting.Power)*0.15
+}
+
+func aerialSkill(p models.Player) float64 {
+	return float64(p.Technical.Defending.Heading.Accuracy)*0.5 +
+		float64(p.Technical.Defending.Heading.Power)*0.2 +
+		float64(p.Technical.Defending.Jumping)*0.3
+}
+
+func keeperSkill(keeper models.Player) float64 {
+	return float64(keeper.Technical.Goalkeeping.Reflexes)*0.4 +
+		float64(keeper.Technical.Goalkeeping.Positioning)*0.4 +
+		float64(keeper.Technical.Goalkeeping.Reactions)*0.2
+}
+
+// keeping is how well a keeper is keeping goal right now: tired, injured or
+// an outfield player stood in goal all keep worse.
+func (s *SimulationState) keeping(team models.Team, keeper models.Player) float64 {
+	return keeperSkill(keeper) * s.condition(team, keeper)
+}
+
+// freeKickTaker is the team's specialist for free kicks within range of
+// goal, and the best long passer for deeper ones. Penalties go to the
+// penalty taker.
+func (s *SimulationState) freeKickTaker(team models.Team, kickType EventType) *models.Player {
+	if kickType == ETPenalty {
+		return s.penaltyTaker(team)
+	}
+	if kickType == ETFreeKickOnGoal {
+		return bestBy(team.Players, freeKickSkill)
+	}
+	return bestBy(team.Players, func(p models.Player) float64 {
+		return float64(p.Technical.Passing.LongPass)
+	})
+}
+
+// freeKickOnGoal decides whether the taker goes for goal or puts it into the
+// box; good free kick takers are more likely to shoot.
+func (s *SimulationState) freeKickOnGoal(e Event) Event {
+	team := s.Simulation.lineup(e.Team)
+	taker := e.FinishingPlayer
+
+	shootChance := 0.4 + float64(taker.Technical.FreeKicks)/200.0
+	if s.Simulation.RandomFloat() < shootChance {
+		return s.directFreeKick(team, taker)
+	}
+	return s.freeKickCross(team, taker)
+}
+
+// directFreeKick goes in as often as its xG says for a taker as good at free
+// kicks as an average finisher is at shooting. Otherwise it's blocked by the
+// wall, goes wide or is saved.
+func (s *SimulationState) directFreeKick(team models.Team, taker *models.Player) Event {
+	opposingTeam := s.Simulation.opposingTeam(team)
+	skill := freeKickSkill(*taker) * s.training(team, models.SetPieces)
+	meta := s.shotMeta(team, *taker, ShotFreeKick, AssistNone)
+	meta["free_kick"] = true
+
+	if s.scores(meta, skill) {
+		return Event{
+			Type:            ETGoal,
+			Team:            team,
+			StartingPlayer:  taker,
+			FinishingPlayer: taker,
+			EventMeta:       meta,
+		}
+	}
+
+	wall := s.wall(opposingTeam)
+	wallHeight := 0.0
+	for _, player := range wall {
+		wallHeight += float64(player.Technical.Defending.Jumping)*0.5 + float64(player.Technical.Defending.Blocking)*0.5
+	}
+	if len(wall) > 0 {
+		wallHeight /= float64(len(wall))
+	}
+
+	if len(wall) > 0 && s.Simulation.RandomFloat() < helpers.Sigmoid((wallHeight-skill)/15)*0.6 {
+		blocker := bestBy(wall, func(p models.Player) float64 { return float64(p.Technical.Defending.Blocking) })
+		meta["blocked"] = true
+		return Event{
+			Type:            ETInterception,
+			Team:            opposingTeam,
+			StartingPlayer:  taker,
+			FinishingPlayer: blocker,
+			EventMeta:       meta,
+		}
+	}
+
+	if s.Simulation.RandomFloat() > skill/100.0*0.6 {
+		return Event{
+			Type:            ETMiss,
+			Team:            team,
+			StartingPlayer:  taker,
+			FinishingPlayer: taker,
+			EventMeta:       meta,
+		}
+	}
+
+	save := s.save(taker, team)
+	save.EventMeta = meta
+	return save
+}
+
+// freeKickCross is an aerial duel between the best header in each box.
+func (s *SimulationState) freeKickCross(team models.Team, taker *models.Player) Event {
+	return s.aerialDelivery(team, taker, "free_kick")
+}
+
+// aerialDelivery puts a set piece into the box for the best header of the
+// ball to attack, against the opposition's best header. The event meta marks
+// which set piece it came from.
+func (s *SimulationState) aerialDelivery(team models.Team, taker *models.Player, setPiece string) Event {
+	opposingTeam := s.Simulation.opposingTeam(team)
+	target := bestBy(slices.DeleteFunc(slices.Clone(team.Players), func(p models.Player) bool {
+		return p.Number == taker.Number
+	}), aerialSkill)
+	defender := bestBy(opposingTeam.Players, aerialSkill)
+	if target == nil || defender == nil {
+		return s.turnover(team, taker)
+	}
+
+	attack := (float64(taker.Technical.Passing.Cross)*0.5 + aerialSkill(*target)*0.5) * s.training(team, models.SetPieces)
+	defence := aerialSkill(*defender) * s.training(opposingTeam, models.Defense)
+	if s.Simulation.RandomFloat() < helpers.Sigmoid((attack-defence)/10) {
+		return s.setPieceHeader(team, taker, target, setPiece)
+	}
+
+	if s.Simulation.RandomFloat() < clearanceOutChance {
+		return s.cornerKick(team, "clearance")
+	}
+
+	return Event{
+		Type:            ETInterception,
+		Team:            opposingTeam,
+		StartingPlayer:  taker,
+		FinishingPlayer: defender,
+		EventMeta:       EventMeta{setPiece: true, "cleared": true},
+	}
+}
+
+// setPieceHeader is the target getting their head to the delivery. It goes in
+// as often as its xG says for a header of the ball as good as an average
+// finisher is at shooting; otherwise it's off target or saved.
+func (s *SimulationState) setPieceHeader(team models.Team, taker, target *models.Player, setPiece string) Event {
+	// the last event keeps where everyone was when it happened
+	locations := maps.Clone(s.Locations)
+	locations[team.Name] = maps.Clone(locations[team.Name])
+	locations[team.Name][target.Number] = s.frame(team, Point{X: pitchLength - headerRange, Y: pitchWidth / 2})
+	s.Locations = locations
+
+	skill := aerialSkill(*target) * s.training(team, models.SetPieces)
+	meta := s.shotMeta(team, *target, ShotHeader, AssistSetPiece)
+	meta[setPiece] = true
+
+	if s.scores(meta, skill) {
+		return Event{
+			Type:            ETGoal,
+			Team:            team,
+			StartingPlayer:  taker,
+			FinishingPlayer: target,
+			EventMeta:       meta,
+		}
+	}
+
+	if s.Simulation.RandomFloat() > skill/100.0*0.6 {
+		return Event{
+			Type:            ETMiss,
+			Team:            team,
+			StartingPlayer:  taker,
+			FinishingPlayer: target,
+			EventMeta:       meta,
+		}
+	}
+
+	return withMeta(s.save(target, team), meta)
+}
+
+// freeKickDefensiveHalf is played short to a teammate, or long if the taker
+// can find a forward.
+func (s *SimulationState) freeKickDefensiveHalf(e Event) Event {
+	team := s.Simulation.lineup(e.Team)
+	taker := e.FinishingPlayer
+
+	longChance := float64(taker.Technical.Passing.LongPass) / 200.0
+	if s.Simulation.RandomFloat() < longChance {
+		receiver := team.ChooseReceiver(*taker, false, true, s.Simulation.RandomFloat)
+		synergy := s.synergy(team, *taker, *receiver)
+		if !s.evaluateLongPass(team, *taker, *receiver, synergy) {
+			return withMeta(s.turnover(team, taker), EventMeta{"synergy": rounded(synergy)})
+		}
+		return Event{
+			Type:            ETPass,
+			Team:            team,
+			StartingPlayer:  taker,
+			FinishingPlayer: receiver,
+			EventMeta:       EventMeta{"free_kick": true, "synergy": rounded(synergy)},
+		}
+	}
+
+	// nobody closes down a short free kick
+	return Event{
+		Type:            ETPass,
+		Team:            team,
+		StartingPlayer:  taker,
+		FinishingPlayer: team.ChooseReceiver(*taker, false, false, s.Simulation.RandomFloat),
+		EventMeta:       EventMeta{"free_kick": true},
+	}
+}
+
+// wall is made up of the team's best blockers.
+func (s *SimulationState) wall(team models.Team) []models.Player {
+	outfield := slices.DeleteFunc(slices.Clone(team.Players), func(p models.Player) bool {
+		return p.Position == models.Goalkeeper
+	})
+	slices.SortStableFunc(outfield, func(a, b models.Player) int {
+		return b.Technical.Defending.Blocking - a.Technical.Defending.Blocking
+	})
+	return outfield[:min(wallSize, len(outfield))]
+}
+
+func penaltySkill(p models.Player) float64 {
+	return float64(p.Technical.Penalties)*0.7 + float64(p.Composure)*0.3
+}
+
+// penaltyTaker is the team's designated taker if they're still on the pitch,
+// otherwise whoever is best from the spot.
+func (s *SimulationState) penaltyTaker(team models.Team) *models.Player {
+	for i := range team.Players {
+		if team.Players[i].Number == team.Strategy.PenaltyTaker {
+			return &team.Players[i]
+		}
+	}
+	return bestBy(team.Players, penaltySkill)
+}
+
+// penalty is taker against keeper: most penalties are on target, and the
+// keeper's chance of saving one depends on how they compare to the taker.
+func (s *SimulationState) penalty(team models.Team, taker *models.Player) Event {
+	opposingTeam := s.Simulation.opposingTeam(team)
+	keeper := opposingTeam.SearchPlayers(models.PlayerSearchOptions{
+		Positions: []models.PlayerPosition{models.Goalkeeper},
+	})
+	skill := penaltySkill(*taker) * s.training(team, models.Penalties)
+	meta := s.shotMeta(team, *taker, ShotPenalty, AssistNone)
+	meta["penalty"] = true
+
+	onTarget := min(0.97, max(0.7, 0.85+(skill-70)/400))
+	if s.Simulation.RandomFloat() > onTarget {
+		return Event{
+			Type:            ETMiss,
+			Team:            team,
+			StartingPlayer:  taker,
+			FinishingPlayer: taker,
+			EventMeta:       meta,
+		}
+	}
+
+	saveChance := 0.36 * helpers.Sigmoid((s.keeping(opposingTeam, keeper)-skill)/15)
+	if s.Simulation.RandomFloat() < saveChance {
+		return Event{
+			Type:            ETSave,
+			Team:            opposingTeam,
+			StartingPlayer:  taker,
+			FinishingPlayer: &keeper,
+			EventMeta:       meta,
+		}
+	}
+
+	return Event{
+		Type:            ETGoal,
+		Team:            team,
+		StartingPlayer:  taker,
+		FinishingPlayer: taker,
+		EventMeta:       meta,
+	}
+}
@@ -2,7 +2,6 @@ package simulation
 
 import (
 	"fmt"
-	"math/rand"
 	"slices"
 	"time"
 
@@ -11,25 +10,27 @@ import (
 )
 
 type Simulation struct {
+	Seed              int64
+	Seeded            bool // whether Seed replays the match
+	Rules             Rules
+	PlayStyles        PlayStyles
 	Match             Match
 	KickoffTeam       models.Team
 	State             *SimulationState
 	RandomFloat       func() float64
-	SynergyMultiplier int
+	SynergyMultiplier float64 // how much morale, chemistry and form matter
 	TacticalCounters  map[int]TacticalCounter
 	Pitch             *Pitch
+	Sinks             []EventSink
 }
 
 func (sim *Simulation) Run() {
-	done := make(chan struct{})
-
-	go func() {
-		sim.State.Listen()
-		close(done)
-	}()
-
 	sim.Pitch = NewPitch(&sim.Match)
-	sim.Pitch.Draw()
+	for _, sink := range sim.Sinks {
+		if drawer, ok := sink.(PitchSink); ok {
+			drawer.DrawPitch(sim.Pitch)
+		}
+	}
 
 	sim.State.CaptureEvent(
 		sim.State.startingEvent(sim.KickoffTeam),
@@ -37,14 +38,47 @@ func (sim *Simulation) Run() {
 
 	sim.State.runGame()
 
-	close(sim.State.EventQueue)
+	sim.State.Outcome = &Outcome{
+		Seed:            sim.Seed,
+		Seeded:          sim.Seeded,
+		HomeTeam:        sim.Match.H.Name,
+		AwayTeam:        sim.Match.A.Name,
+		HomeScore:       sim.State.HomeScore,
+		AwayScore:       sim.State.AwayScore,
+		HomeYellowCards: sim.State.HomeYellowCards,
+		AwayYellowCards: sim.State.AwayYellowCards,
+		HomeRedCards:    sim.State.HomeRedCards,
+		AwayRedCards:    sim.State.AwayRedCards,
+		HomeOffsides:    sim.State.HomeOffsides,
+		AwayOffsides:    sim.State.AwayOffsides,
+		HomeXG:          rounded(sim.State.HomeXG),
+		AwayXG:          rounded(sim.State.AwayXG),
+		Injuries:        sim.State.Injuries,
+		Bookings:        sim.State.Bookings,
+		ExtraTime:       sim.State.ExtraTimeStarted,
+	}
+	if sim.State.ShootoutStarted {
+		sim.State.Outcome.Shootout = &ShootoutScore{
+			HomeScore: sim.State.HomeShootoutScore,
+			AwayScore: sim.State.AwayShootoutScore,
+		}
+	}
+}
 
-	<-done
+func (sim *Simulation) AddSink(sink EventSink) {
+	sim.Sinks = append(sim.Sinks, sink)
+}
 
-	sim.State.Outcome = &Outcome{
-		HomeScore: sim.State.HomeScore,
-		AwayScore: sim.State.AwayScore,
+// team is the side as it currently stands, with any substitutions made.
+func (sim *Simulation) team(team models.Team) *models.Team {
+	if sim.Match.A.Name == team.Name {
+		return &sim.Match.A
 	}
+	return &sim.Match.H
+}
+
+func (sim *Simulation) lineup(team models.Team) models.Team {
+	return *sim.team(team)
 }
 
 func (sim *Simulation) opposingTeam(team models.Team) models.Team {
@@ -55,11 +89,13 @@ func (sim *Simulation) opposingTeam(team models.Team) models.Team {
 	}
 }
 
-func CreateSimulation(home, away models.Team) *Simulation {
-	randGen := rand.New(rand.NewSource(time.Now().UnixNano()))
-	randomFloat := func() float64 { return randGen.Float64() }
+func CreateSimulation(home, away models.Team, opts ...Option) *Simulation {
+	cfg := newConfig(opts)
+	randomFloat := cfg.randomFloat
 
 	state := &SimulationState{
+		Start:             cfg.start,
+		Time:              cfg.start,
 		HomeScore:         0,
 		AwayScore:         0,
 		HomeYellowCards:   0,
@@ -72,20 +108,37 @@ func CreateSimulation(home, away models.Team) *Simulation {
 		AwayTeamAttacking: false,
 		FullTime:          false,
 		Events:            make([]Event, 0),
-		EventQueue:        make(chan Event, 100),
+		Substitutions:     make(map[string][]Substitution),
+		EventQueue:        &EventQueue{},
 	}
 
 	state.registerTriggers()
 
+	// the lineups change during the match, so they mustn't share a backing
+	// array with the caller's teams
+	for _, team := range []*models.Team{&home, &away} {
+		team.Players = slices.Clone(team.Players)
+		team.Bench = slices.Clone(team.Bench)
+	}
+
 	sim := &Simulation{
+		Seed:              cfg.seed,
+		Seeded:            cfg.seeded,
+		Rules:             cfg.rules,
+		PlayStyles:        cfg.playStyles,
 		Match:             Match{H: home, A: away},
 		State:             state,
-		SynergyMultiplier: 1,
+		SynergyMultiplier: cfg.synergy,
 		TacticalCounters:  make(map[int]TacticalCounter),
 		RandomFloat:       randomFloat,
+		Sinks:             cfg.sinks,
 	}
 
 	state.Simulation = sim
+	state.initEnergy(home, away)
+	state.initMorale(home, away)
+	state.Ball = centreSpot
+	state.arrange(Event{})
 
 	coinFlip := randomFloat()
 	if coinFlip < 0.5 {
@@ -107,48 +160,107 @@ type SimulationState struct {
 	SecondHalfStarted    bool
 	SecondHalfEnded      bool
 	SecondHalfExtraEnded bool
+	ExtraTimeStarted     bool
+	ExtraTimeKickoffTeam models.Team
+	ShootoutStarted      bool
 	FullTime             bool
+	Period               Period
 	HomeScore            int
 	AwayScore            int
 	HomeYellowCards      int
 	AwayYellowCards      int
 	HomeRedCards         int
 	AwayRedCards         int
+	HomeOffsides         int
+	AwayOffsides         int
+	HomeShootoutScore    int
+	AwayShootoutScore    int
+	HomeXG               float64
+	AwayXG               float64
 	HomeMomentum         float64
 	AwayMomentum         float64
 	HomeTeamAttacking    bool
 	AwayTeamAttacking    bool
 	Stalemate            bool
-	FirstHalfExtraTime   time.Duration // seconds
-	SecondHalfExtraTime  time.Duration // seconds
+	PenaltyPending       bool                      // given but not yet taken
+	PenaltyAt            time.Duration             // when it will be taken
+	FirstHalfExtraTime   time.Duration             // seconds
+	SecondHalfExtraTime  time.Duration             // seconds
+	Substitutions        map[string][]Substitution // by team name
+	Bookings             []Booking
+	Injuries             []Injury
+	Energy               map[string]map[models.PlayerNumber]float64 // by team name, out of 100
+	Morale               map[string]float64                         // by team name, out of 100
+	Ball                 Point
+	Locations            map[string]map[models.PlayerNumber]Point   // by team name
+	Form                 map[string]map[models.PlayerNumber]float64 // by team name, out of 100
 	Triggers             map[EventType]func(e Event)
-	EventQueue           chan Event
+	EventQueue           *EventQueue
 	Events               []Event
 	Outcome              *Outcome
 }
 
-func (s *SimulationState) Listen() {
-	for event := range s.EventQueue {
-		s.Events = append(s.Events, event)
-		if trigger, exists := s.Triggers[event.Type]; exists {
-			trigger(event)
-		} else {
-			s.log(event)
-		}
+func (s *SimulationState) handle(event Event) {
+	if s.holdWhistle(event) {
+		return
+	}
+	event.Time = s.elapsed()
+	event.Period = s.Period
+	s.Ball = s.ballFor(event)
+	s.arrange(event)
+	event.Ball, event.Locations = s.Ball, s.Locations
+	s.Events = append(s.Events, event)
+	if penalty, _ := event.EventMeta["penalty"].(bool); penalty {
+		s.PenaltyPending = false
+	}
+	if trigger, exists := s.Triggers[event.Type]; exists {
+		trigger(event)
+	} else {
+		s.log(event)
 	}
+	s.exert(event)
+	s.reflect(event)
+	s.tallyXG(event)
 }
 
+// CaptureEvent schedules an event to happen straight away.
 func (s *SimulationState) CaptureEvent(e Event) {
+	s.scheduleAt(s.elapsed(), e)
+}
+
+// CaptureEventAfter schedules an event to happen once roughly d of play has
+// passed; the actual delay is somewhere between 0 and 2d.
+func (s *SimulationState) CaptureEventAfter(d time.Duration, e Event) {
+	s.scheduleAt(s.elapsed()+s.delay(d), e)
+}
+
+func (s *SimulationState) delay(d time.Duration) time.Duration {
+	rand := s.Simulation.RandomFloat() * 2.0 // random number between 0 and 2
+	return time.Duration(d.Seconds()*rand) * time.Second
+}
+
+// holdWhistle puts off the end of a period until a penalty that's been given
+// has been taken, and reports whether it did.
+func (s *SimulationState) holdWhistle(e Event) bool {
+	switch e.Type {
+	case ETEndOfFirstHalfExtraTime, ETEndOfSecondHalfExtraTime, ETEndOfExtraTimeFirstHalf, ETEndOfExtraTimeSecondHalf:
+	default:
+		return false
+	}
+	if !s.PenaltyPending {
+		return false
+	}
+	// scheduled after the kick, so it still comes first if they're due together
+	s.scheduleAt(max(s.PenaltyAt, s.elapsed()), e)
+	return true
+}
+
+func (s *SimulationState) scheduleAt(at time.Duration, e Event) {
 	if s.FullTime {
 		return
 	}
 
-	select {
-	case s.EventQueue <- e:
-		// sent successfully
-	default:
-		// could log a warning if needed
-	}
+	s.EventQueue.Schedule(at, e)
 }
 
 func (s *SimulationState) LastEvent() Event {
@@ -169,29 +281,24 @@ func (s *SimulationState) Timestamp() string {
 	return fmt.Sprintf("%02d:%02d", int(duration.Minutes()), seconds)
 }
 
+func (s *SimulationState) elapsed() time.Duration {
+	return s.Time.Sub(s.Start)
+}
+
 func (s *SimulationState) runGame() {
-	firstHalfDuration := 45 * time.Minute
-	secondHalfDuration := 45 * time.Minute
+	s.scheduleAt(halfDuration, Event{Type: ETEndOfFirstHalf})
 
 	for !s.FullTime {
-		elapsed := s.Time.Sub(s.Start)
-
-		switch {
-		case !s.FirstHalfEnded && elapsed >= firstHalfDuration:
-			s.CaptureEvent(Event{Type: ETEndOfFirstHalf})
-			s.FirstHalfEnded = true
-		case s.FirstHalfEnded && !s.FirstHalfExtraEnded && elapsed >= firstHalfDuration+s.FirstHalfExtraTime:
-			s.CaptureEvent(Event{Type: ETEndOfFirstHalfExtraTime})
-			s.FirstHalfExtraEnded = true
-			s.SecondHalfStarted = true
-		case s.SecondHalfStarted && !s.SecondHalfEnded && elapsed >= firstHalfDuration+secondHalfDuration:
-			s.CaptureEvent(Event{Type: ETEndOfSecondHalf})
-			s.SecondHalfEnded = true
-		case s.SecondHalfEnded && !s.SecondHalfExtraEnded && elapsed >= firstHalfDuration+secondHalfDuration+s.SecondHalfExtraTime:
-			s.CaptureEvent(Event{Type: ETEndOfSecondHalfExtraTime})
-			s.SecondHalfExtraEnded = true
-			s.FullTime = true
+		next, ok := s.EventQueue.Next()
+		if !ok {
+			// nothing was scheduled after the last event, so play can't resume
+			return
 		}
+
+		s.drainEnergy(next.At - s.elapsed())
+		s.settleMomentum(next.At - s.elapsed())
+		s.Time = s.Start.Add(next.At)
+		s.handle(next.Event)
 	}
 }
 
@@ -199,162 +306,305 @@ func (s *SimulationState) registerTriggers() {
 	s.Triggers = make(map[EventType]func(e Event))
 	s.Triggers[ETEndOfFirstHalf] = func(e Event) {
 		s.log(e)
+		s.FirstHalfEnded = true
+		s.scheduleAt(s.elapsed()+s.FirstHalfExtraTime, Event{Type: ETEndOfFirstHalfExtraTime})
 	}
 	s.Triggers[ETEndOfFirstHalfExtraTime] = func(e Event) {
 		s.log(e)
+		s.FirstHalfExtraEnded = true
+		s.SecondHalfStarted = true
+		s.Period = PeriodSecondHalf
+		// whatever was about to happen in the first half never does
+		s.EventQueue.Clear()
 		// time is reset
-		s.Time = s.Start.Add(time.Minute * 45)
+		s.Time = s.Start.Add(halfDuration)
+		s.scheduleAt(2*halfDuration, Event{Type: ETEndOfSecondHalf})
+		s.CaptureEvent(
+			s.startingEvent(s.Simulation.opposingTeam(s.Simulation.KickoffTeam)),
+		)
+	}
+	s.Triggers[ETEndOfSecondHalf] = func(e Event) {
+		s.log(e)
+		s.SecondHalfEnded = true
+		s.scheduleAt(s.elapsed()+s.SecondHalfExtraTime, Event{Type: ETEndOfSecondHalfExtraTime})
 	}
 	s.Triggers[ETEndOfSecondHalfExtraTime] = func(e Event) {
 		s.log(e)
-		if s.HomeScore < s.AwayScore {
-			s.HomeMomentum += 0.5
-		} else {
-			s.AwayMomentum += 0.5
+		s.SecondHalfExtraEnded = true
+		if s.Simulation.Rules.Knockout && s.HomeScore == s.AwayScore {
+			s.startExtraTime()
+			return
 		}
+		s.endMatch()
 	}
-	s.Triggers[ETPass] = func(e Event) {
+	s.Triggers[ETEndOfExtraTimeFirstHalf] = func(e Event) {
 		s.log(e)
-		s.addTime(time.Second * 3)
+		s.Period = PeriodExtraTimeSecondHalf
+		s.EventQueue.Clear()
+		s.Time = s.Start.Add(2*halfDuration + extraTimeDuration)
+		s.scheduleAt(2*halfDuration+2*extraTimeDuration, Event{Type: ETEndOfExtraTimeSecondHalf})
 		s.CaptureEvent(
+			s.startingEvent(s.Simulation.opposingTeam(s.ExtraTimeKickoffTeam)),
+		)
+	}
+	s.Triggers[ETEndOfExtraTimeSecondHalf] = func(e Event) {
+		s.log(e)
+		s.EventQueue.Clear()
+		if s.HomeScore == s.AwayScore {
+			s.penaltyShootout()
+		}
+		s.endMatch()
+	}
+	s.Triggers[ETPass] = func(e Event) {
+		s.log(e)
+		s.CaptureEventAfter(
+			time.Second*3,
 			s.action(e),
 		)
 	}
 	s.Triggers[ETCross] = func(e Event) {
 		s.log(e)
-		s.addTime(time.Second * 3)
-		s.CaptureEvent(
+		s.CaptureEventAfter(
+			time.Second*3,
 			s.action(e),
 		)
 	}
 	s.Triggers[ETDribble] = func(e Event) {
 		s.log(e)
-		s.addTime(time.Second * 5)
-		s.CaptureEvent(
+		s.CaptureEventAfter(
+			time.Second*5,
 			s.action(e),
 		)
 	}
 	s.Triggers[ETGoal] = func(e Event) {
 		s.log(e)
-		s.addTime(time.Minute * 2)
 		if s.isHome(e.Team) {
 			s.HomeScore++
-			s.HomeMomentum += 0.1
-			s.AwayMomentum -= 0.2
 		} else {
 			s.AwayScore++
-			s.AwayMomentum += 0.1
-			s.HomeMomentum -= 0.2
 		}
-		s.CaptureEvent(
+		s.CaptureEventAfter(
+			time.Minute*2,
 			s.goal(e),
 		)
 	}
 	s.Triggers[ETReset] = func(e Event) {
 		s.log(e)
-		s.addTime(time.Second * 3)
-		s.CaptureEvent(
+		s.considerSubstitutions()
+		s.CaptureEventAfter(
+			time.Second*3,
 			s.reset(e),
 		)
 	}
 	s.Triggers[ETInterception] = func(e Event) {
 		s.log(e)
-		s.addTime(time.Second * 3)
-		s.CaptureEvent(
+		// a tackle, rather than a blocked free kick or a cleared cross
+		blocked, _ := e.EventMeta["blocked"].(bool)
+		cleared, _ := e.EventMeta["cleared"].(bool)
+		if !blocked && !cleared {
+			s.considerInjury(s.Simulation.opposingTeam(e.Team), e.StartingPlayer, tackleInjuryChance)
+		}
+		s.CaptureEventAfter(
+			time.Second*3,
 			s.action(e),
 		)
 	}
 	s.Triggers[ETPossession] = func(e Event) {
 		s.log(e)
-		s.addTime(time.Second * 3)
-		s.CaptureEvent(
+		s.CaptureEventAfter(
+			time.Second*3,
 			s.action(e),
 		)
 	}
-	s.Triggers[ETYellowCard] = func(e Event) {
+	s.Triggers[ETFoul] = func(e Event) {
 		s.log(e)
-		s.CaptureEvent(
-			s.freeKick(e),
+		s.considerInjury(s.Simulation.opposingTeam(e.Team), e.StartingPlayer, foulInjuryChance)
+		s.replaceInjured()
+		switch s.foulSeverity(*e.FinishingPlayer, e.Team) {
+		case ETYellowCard:
+			s.CaptureEvent(Event{
+				Type:            ETYellowCard,
+				Team:            e.Team,
+				StartingPlayer:  e.StartingPlayer,
+				FinishingPlayer: e.FinishingPlayer,
+			})
+		case ETRedCard:
+			s.CaptureEvent(Event{
+				Type:            ETRedCard,
+				Team:            e.Team,
+				StartingPlayer:  e.StartingPlayer,
+				FinishingPlayer: e.FinishingPlayer,
+			})
+		default:
+			duration := time.Second * 3
+			s.addExtraTime(duration)
+			s.CaptureEventAfter(
+				duration,
+				s.freeKick(e),
+			)
+		}
+	}
+	s.Triggers[ETFreeKickOnGoal] = func(e Event) {
+		s.log(e)
+		s.CaptureEventAfter(
+			time.Second*15,
+			s.freeKickOnGoal(e),
 		)
-		duration := time.Second * 3
-		s.addTime(duration)
+	}
+	s.Triggers[ETFreeKickDefensiveHalf] = func(e Event) {
+		s.log(e)
+		s.CaptureEventAfter(
+			time.Second*10,
+			s.freeKickDefensiveHalf(e),
+		)
+	}
+	s.Triggers[ETPenalty] = func(e Event) {
+		s.log(e)
+		duration := time.Second * 60
 		s.addExtraTime(duration)
+		// it's taken even if time runs out before then
+		s.PenaltyPending = true
+		s.PenaltyAt = s.elapsed() + s.delay(duration)
+		s.scheduleAt(s.PenaltyAt, s.penalty(s.Simulation.lineup(e.Team), e.FinishingPlayer))
+	}
+	s.Triggers[ETMiss] = func(e Event) {
+		s.log(e)
+		s.CaptureEventAfter(
+			time.Second*5,
+			s.missRestart(e),
+		)
+	}
+	s.Triggers[ETOffside] = func(e Event) {
+		s.log(e)
+		if s.isHome(e.Team) {
+			s.HomeOffsides++
+		} else {
+			s.AwayOffsides++
+		}
+		s.replaceInjured()
+		s.CaptureEventAfter(
+			time.Second*10,
+			s.offsideFreeKick(e),
+		)
+	}
+	s.Triggers[ETCorner] = func(e Event) {
+		s.log(e)
+		s.replaceInjured()
+		s.CaptureEventAfter(
+			time.Second*20,
+			s.corner(e),
+		)
+	}
+	s.Triggers[ETGoalKick] = func(e Event) {
+		s.log(e)
+		s.replaceInjured()
+		s.CaptureEventAfter(
+			time.Second*10,
+			s.goalKeeperKick(e),
+		)
+	}
+	s.Triggers[ETThrowIn] = func(e Event) {
+		s.log(e)
+		s.replaceInjured()
+		s.CaptureEventAfter(
+			time.Second*5,
+			s.takeThrowIn(e),
+		)
+	}
+	s.Triggers[ETYellowCard] = func(e Event) {
+		s.log(e)
 		if s.isHome(e.Team) {
 			s.HomeYellowCards++
 		} else {
 			s.AwayYellowCards++
 		}
-	}
-	s.Triggers[ETRedCard] = func(e Event) {
-		s.CaptureEvent(
+		booking := s.booking(e.Team, *e.FinishingPlayer)
+		booking.Yellows++
+		if booking.Yellows == 2 {
+			s.handle(Event{
+				Type:            ETRedCard,
+				Team:            e.Team,
+				StartingPlayer:  e.StartingPlayer,
+				FinishingPlayer: e.FinishingPlayer,
+				EventMeta:       EventMeta{"second_yellow": true},
+			})
+			return
+		}
+		duration := time.Second * 3
+		s.addExtraTime(duration)
+		s.CaptureEventAfter(
+			duration,
 			s.freeKick(e),
 		)
-		duration := time.Second * 20
-		s.addTime(duration)
-		s.addExtraTime(duration)
+	}
+	s.Triggers[ETRedCard] = func(e Event) {
+		s.log(e)
 		if s.isHome(e.Team) {
 			s.HomeRedCards++
 		} else {
-			s.HomeRedCards++
+			s.AwayRedCards++
 		}
+		s.sendOff(e.Team, *e.FinishingPlayer)
+		duration := time.Second * 20
+		s.addExtraTime(duration)
+		s.CaptureEventAfter(
+			duration,
+			s.freeKick(e),
+		)
 	}
 	s.Triggers[ETSave] = func(e Event) {
 		s.log(e)
-		s.addTime(time.Second * 3)
 		s.addExtraTime(time.Second * 1)
-		s.CaptureEvent(
-			s.goalKeeperKick(e),
+		s.considerSubstitutions()
+		s.CaptureEventAfter(
+			time.Second*3,
+			s.saveRestart(e),
 		)
 	}
+	s.Triggers[ETInjury] = func(e Event) {
+		s.log(e)
+		severity, _ := e.EventMeta["severity"].(InjurySeverity)
+		recoveryDays, _ := e.EventMeta["recovery_days"].(int)
+		s.Injuries = append(s.Injuries, Injury{
+			Team:         e.Team.Name,
+			Player:       e.FinishingPlayer.Name,
+			Number:       e.FinishingPlayer.Number,
+			Severity:     severity,
+			RecoveryDays: recoveryDays,
+		})
+		s.addExtraTime(time.Minute)
+	}
+	s.Triggers[ETSubstitution] = func(e Event) {
+		s.log(e)
+		s.addExtraTime(time.Second * 30)
+		s.swap(e)
+	}
 }
 
-func (s *SimulationState) addTime(d time.Duration) {
-	rand := s.Simulation.RandomFloat() * 2.0 // random number between 0 and 2
-	s.Time = s.Time.Add(time.Duration(d.Seconds()*rand) * time.Second)
+func (s *SimulationState) endMatch() {
+	s.FullTime = true
+	s.Period = PeriodFullTime
+	if s.HomeScore < s.AwayScore {
+		s.HomeMomentum += 0.5
+	} else {
+		s.AwayMomentum += 0.5
+	}
 }
 
+// addExtraTime adds stoppages to the half being played, up to maxExtraTime.
+// Routine restarts don't count.
 func (s *SimulationState) addExtraTime(d time.Duration) {
 	if !s.FirstHalfEnded {
-		s.FirstHalfExtraTime += d
+		s.FirstHalfExtraTime = min(maxExtraTime, s.FirstHalfExtraTime+d)
 	}
 	if s.SecondHalfStarted && !s.SecondHalfEnded {
-		s.SecondHalfExtraTime += d
+		s.SecondHalfExtraTime = min(maxExtraTime, s.SecondHalfExtraTime+d)
 	}
 }
 
 func (s *SimulationState) log(e Event) {
-	switch e.Type {
-	case ETPass:
-		fmt.Printf("(%s) %s passes to %s\n", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
-	case ETGoal:
-		fmt.Printf("(%s) %s shoots and scores!\n", s.Timestamp(), e.FinishingPlayer.Name)
-	case ETReset:
-		fmt.Printf("(%s) The game restarts after the goal\n", s.Timestamp())
-	case ETCross:
-		fmt.Printf("(%s) %s crosses to %s\n", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
-	case ETDribble:
-		fmt.Printf("(%s) %s is dribbling with the ball\n", s.Timestamp(), e.StartingPlayer.Name)
-	case ETInterception:
-		fmt.Printf("(%s) %s loses the ball to %s\n", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
-	case ETPossession:
-		fmt.Printf("(%s) %s has the ball\n", s.Timestamp(), e.FinishingPlayer.Name)
-	case ETYellowCard:
-		fmt.Printf("(%s) %s is given a yellow card for a foul\n", s.Timestamp(), e.FinishingPlayer.Name)
-	case ETRedCard:
-		fmt.Printf("(%s) %s is shown a red card for a bad foul\n", s.Timestamp(), e.FinishingPlayer.Name)
-	case ETSave:
-		fmt.Printf("(%s) %s took a shot but it was saved by %s\n", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
-	case ETEndOfFirstHalf:
-		fmt.Printf("(%s) %d minutes extra time added in the first half\n", s.Timestamp(), int(s.FirstHalfExtraTime.Minutes()))
-	case ETEndOfFirstHalfExtraTime:
-		fmt.Printf("(%s) The whistle blows for the end of the first half\n", s.Timestamp())
-	case ETEndOfSecondHalf:
-		fmt.Printf("(%s) %d minutes extra time added in the second half\n", s.Timestamp(), int(s.SecondHalfExtraTime.Minutes()))
-	case ETEndOfSecondHalfExtraTime:
-		fmt.Printf("(%s) The full time whistle blows\n", s.Timestamp())
-		// case ETRestart:
-		// 	// Assuming this special case doesn't need a full Event object
-		// 	fmt.Printf("(%s) The game restarts.\n", s.Timestamp())
+	for _, sink := range s.Simulation.Sinks {
+		sink.Receive(s, e)
 	}
 }
 
@@ -388,20 +638,21 @@ func (s *SimulationState) startingEvent(team models.Team) Event {
 }
 
 func (s *SimulationState) goalKeeperKick(e Event) Event {
+	team := s.Simulation.lineup(e.Team)
 	coinFlip := s.Simulation.RandomFloat()
 	var receivingPlayer models.Player
 	if coinFlip < 0.5 {
-		receivingPlayer = e.Team.SearchPlayers(models.PlayerSearchOptions{
+		receivingPlayer = team.SearchPlayers(models.PlayerSearchOptions{
 			Positions: []models.PlayerPosition{models.Striker, models.LeftWinger, models.LeftMidfielder, models.RightWinger, models.RightMidfielder, models.CentreForward, models.CentralAttackingMidfielder},
 		})
 	} else {
-		receivingPlayer = e.Team.SearchPlayers(models.PlayerSearchOptions{
+		receivingPlayer = team.SearchPlayers(models.PlayerSearchOptions{
 			Positions: []models.PlayerPosition{models.LeftBack, models.LeftCentreBack, models.RightCentreBack, models.RightBack},
 		})
 	}
 	return Event{
 		Type:            ETPass,
-		Team:            e.Team,
+		Team:            team,
 		StartingPlayer:  e.FinishingPlayer,
 		FinishingPlayer: &receivingPlayer,
 	}
@@ -416,15 +667,16 @@ func (s *SimulationState) goal(e Event) Event {
 }
 
 func (s *SimulationState) reset(e Event) Event {
-	startingPlayer := e.Team.SearchPlayers(models.PlayerSearchOptions{
+	team := s.Simulation.lineup(e.Team)
+	startingPlayer := team.SearchPlayers(models.PlayerSearchOptions{
 		Positions: []models.PlayerPosition{models.Striker},
 	})
-	receivingPlayer := e.Team.SearchPlayers(models.PlayerSearchOptions{
+	receivingPlayer := team.SearchPlayers(models.PlayerSearchOptions{
 		Positions: []models.PlayerPosition{models.CentralMidfielder},
 	})
 	return Event{
 		Type:            ETPass,
-		Team:            e.Team,
+		Team:            team,
 		StartingPlayer:  &startingPlayer,
 		FinishingPlayer: &receivingPlayer,
 	}
@@ -432,9 +684,10 @@ func (s *SimulationState) reset(e Event) Event {
 
 func (s *SimulationState) freeKick(e Event) Event {
 	opposingTeam := s.Simulation.opposingTeam(e.Team)
-	kickTaker := s.opponentNearestTo(e.FinishingPlayer.Position, opposingTeam.Players)
+	kickType := s.freeKickType(e.StartingPlayer)
+	kickTaker := s.freeKickTaker(opposingTeam, kickType)
 	return Event{
-		Type:            ETFreeKickOnGoal,
+		Type:            kickType,
 		Team:            opposingTeam,
 		StartingPlayer:  kickTaker,
 		FinishingPlayer: kickTaker,
@@ -446,54 +699,56 @@ func (s *SimulationState) action(e Event) Event {
 
 	decision := s.makeDecision(e)
 
-	return s.evaluateDecision(e.Team, player, decision)
+	return s.evaluateDecision(s.Simulation.lineup(e.Team), player, decision)
 }
 
 func (s *SimulationState) evaluateDecision(team models.Team, player *models.Player, decision Decision) Event {
 	switch decision {
 	case DecisionLongPass:
-		if s.evaluateLongPass(team, *player) {
-			receivingPlayer := team.ChooseReceiver(*player, s.underPressure(), true, s.Simulation.RandomFloat)
+		receivingPlayer := team.ChooseReceiver(*player, s.underPressure(), true, s.Simulation.RandomFloat)
+		synergy := s.synergy(team, *player, *receivingPlayer)
+		if s.evaluateLongPass(team, *player, *receivingPlayer, synergy) {
 			return Event{
 				Type:            ETPass,
 				Team:            team,
 				StartingPlayer:  player,
 				FinishingPlayer: receivingPlayer,
+				EventMeta:       EventMeta{"synergy": rounded(synergy)},
 			}
 		} else {
-			return s.turnover(team, player)
+			return withMeta(s.turnover(team, player), EventMeta{"synergy": rounded(synergy)})
 		}
 	case DecisionShortPass:
 		receivingPlayer := team.ChooseReceiver(*player, s.underPressure(), false, s.Simulation.RandomFloat)
-		if s.evaluateShortPass(*player) {
+		synergy := s.synergy(team, *player, *receivingPlayer)
+		if s.evaluateShortPass(team, *player, *receivingPlayer, synergy) {
 			return Event{
 				Type:            ETPass,
 				Team:            team,
 				StartingPlayer:  player,
 				FinishingPlayer: receivingPlayer,
+				EventMeta:       EventMeta{"synergy": rounded(synergy)},
 			}
 		} else {
-			return s.turnover(team, player)
+			return withMeta(s.turnover(team, player), EventMeta{"synergy": rounded(synergy)})
 		}
 	case DecisionDribble:
 		opposingTeam := s.Simulation.opposingTeam(team)
+		composure := rounded(s.composure(team, *player))
 		if s.evaluateDribble(*player, opposingTeam) {
 			return Event{
 				Type:            ETDribble,
 				Team:            team,
 				StartingPlayer:  player,
 				FinishingPlayer: player,
+				EventMeta:       EventMeta{"composure": composure},
 			}
 		} else {
-			return s.turnover(team, player)
+			return withMeta(s.turnover(team, player), EventMeta{"composure": composure})
 		}
 	case DecisionCross:
-		if s.evaluateCross(*player) {
-			nearestTeammate := s.teamMateNearest(player.Position, team.Players)
-			receivingPlayer := team.SearchPlayers(models.PlayerSearchOptions{
-				Positions:  []models.PlayerPosition{nearestTeammate.Position, models.LeftWinger, models.RightWinger, models.Striker, models.CentralAttackingMidfielder, models.CentreForward},
-				Exclusions: map[models.PlayerNumber]string{player.Number: player.Initials()},
-			})
+		if s.evaluateCross(team, *player) {
+			receivingPlayer := s.crossTarget(team, player)
 			return Event{
 				Type:            ETCross,
 				Team:            team,
@@ -501,21 +756,35 @@ func (s *SimulationState) evaluateDecision(team models.Team, player *models.Play
 				FinishingPlayer: &receivingPlayer,
 			}
 		} else {
-			return s.turnover(team, player)
+			return s.blockedCross(team, player)
 		}
 	case DecisionShoot:
-		if s.evaluateShot(team, *player) {
+		shot, assist := s.shotContext(team, *player)
+		meta := s.shotMeta(team, *player, shot, assist)
+		meta["composure"] = rounded(s.composure(team, *player))
+		if s.scores(meta, s.finishing(team, *player)) {
 			return Event{
 				Type:            ETGoal,
 				Team:            team,
 				StartingPlayer:  player,
 				FinishingPlayer: player,
+				EventMeta:       meta,
 			}
+		} else if s.evaluateOnTarget(team, *player) {
+			return withMeta(s.save(player, team), meta)
 		} else {
-			return s.save(player, team)
+			return Event{
+				Type:            ETMiss,
+				Team:            team,
+				StartingPlayer:  player,
+				FinishingPlayer: player,
+				EventMeta:       meta,
+			}
 		}
+	case DecisionThroughBall:
+		return s.throughBall(team, player)
 	case NoDecision:
-		if s.evaluateHold(*player) {
+		if s.evaluateHold(team, *player) {
 			return Event{
 				Type:            ETPossession,
 				Team:            team,
@@ -532,7 +801,25 @@ func (s *SimulationState) evaluateDecision(team models.Team, player *models.Play
 
 func (s *SimulationState) turnover(team models.Team, player *models.Player) Event {
 	opposingTeam := s.Simulation.opposingTeam(team)
-	interceptor := s.opponentNearestTo(player.Position, opposingTeam.Players)
+	interceptor := s.marker(team, *player)
+	if interceptor == nil {
+		interceptor = s.nearestTo(opposingTeam, s.location(team, *player), opposingTeam.Players)
+	}
+	if s.isFoul(*interceptor, opposingTeam) {
+		return Event{
+			Type:            ETFoul,
+			Team:            opposingTeam,
+			StartingPlayer:  player,
+			FinishingPlayer: interceptor,
+		}
+	}
+	if s.Simulation.RandomFloat() < tackleOutChance {
+		// a coin flip for which of them it came off last
+		if s.Simulation.RandomFloat() < 0.5 {
+			return s.throwIn(team)
+		}
+		return s.throwIn(opposingTeam)
+	}
 	return Event{
 		Type:            ETInterception,
 		Team:            opposingTeam,
@@ -554,7 +841,7 @@ func (s *SimulationState) save(player *models.Player, team models.Team) Event {
 	}
 }
 
-func (s *SimulationState) evaluateLongPass(team models.Team, player models.Player) bool {
+func (s *SimulationState) evaluateLongPass(team models.Team, player, receiver models.Player, synergy float64) bool {
 	skill := player.Technical.Passing.LongPass
 	vision := player.TacticalIntelligence.Vision.Passing
 	agility := player.Fitness.Agility
@@ -577,11 +864,17 @@ func (s *SimulationState) evaluateLongPass(team models.Team, player models.Playe
 		float64(momentum)*momentumWeight
 
 	successChance /= 100.0
+	successChance *= s.numbersFactor(team)
+	successChance *= s.condition(team, player)
+	successChance *= s.pressure(team, player)
+	successChance *= s.training(team, models.Passing)
+	successChance *= synergy
+	successChance *= s.passDistance(team, player, receiver, longPassRange)
 
 	return s.Simulation.RandomFloat() < successChance
 }
 
-func (s *SimulationState) evaluateShortPass(player models.Player) bool {
+func (s *SimulationState) evaluateShortPass(team models.Team, player, receiver models.Player, synergy float64) bool {
 	skill := player.Technical.Passing.ShortPass
 	vision := player.TacticalIntelligence.Vision.Passing
 	agility := player.Fitness.Agility
@@ -595,19 +888,34 @@ func (s *SimulationState) evaluateShortPass(player models.Player) bool {
 		float64(agility)*agilityWeight
 
 	successChance /= 100.0
+	successChance *= s.numbersFactor(team)
+	successChance *= s.condition(team, player)
+	successChance *= s.pressure(team, player)
+	successChance *= s.training(team, models.Passing)
+	successChance *= synergy
+	successChance *= s.passDistance(team, player, receiver, shortPassRange)
 
 	return s.Simulation.RandomFloat() < successChance
 }
 
-func (s *SimulationState) evaluateCross(player models.Player) bool {
-	return true
+func (s *SimulationState) evaluateCross(team models.Team, player models.Player) bool {
+	cross := float64(player.Technical.Passing.Cross)
+	vision := float64(player.TacticalIntelligence.Vision.Passing)
+	composure := s.composure(team, player)
+
+	successChance := (cross*0.6 + vision*0.2 + composure*0.2) / 100.0
+	successChance *= s.condition(team, player)
+	successChance *= s.pressure(team, player)
+	successChance *= s.training(team, models.Passing)
+
+	return s.Simulation.RandomFloat() < successChance
 }
 
 func (s *SimulationState) evaluateDribble(player models.Player, opposingTeam models.Team) bool {
 	// base stats from the player
 	skill := float64(player.Technical.Dribbling.Dribbling)
 	agility := float64(player.Technical.Dribbling.Agility)
-	composure := float64(player.Composure)
+	composure := s.composure(s.Simulation.opposingTeam(opposingTeam), player)
 
 	// compute individual ability score
 	dribbleScore := skill*0.5 + agility*0.3 + composure*0.2
@@ -640,98 +948,16 @@ func (s *SimulationState) evaluateDribble(player models.Player, opposingTeam mod
 		dribbleScore = 100
 	}
 
+	team := s.Simulation.opposingTeam(opposingTeam)
 	dribbleScore /= 100.0
+	dribbleScore *= s.numbersFactor(team)
+	dribbleScore *= s.condition(team, player)
+	dribbleScore *= s.pressure(team, player)
 
 	return s.Simulation.RandomFloat() < dribbleScore
 }
 
-func (s *SimulationState) teamMateNearest(attackerPos models.PlayerPosition, players []models.Player) *models.Player {
-	var (
-		bestScore float64
-		closest   *models.Player
-	)
-
-	for i, player := range players {
-		if player.Position == attackerPos {
-			continue
-		}
-
-		score := func() float64 {
-			if attackerPos == players[i].Position {
-				return 1.0
-			}
-
-			adjacentPositions, ok := models.TeammateAdjacents[attackerPos]
-			if !ok {
-				return 0.0
-			}
-
-			if slices.Contains(adjacentPositions, players[i].Position) {
-				return 0.75
-			}
-
-			for _, adjPos := range adjacentPositions {
-				secondDegreePositions, ok := models.TeammateAdjacents[adjPos]
-				if !ok {
-					continue
-				}
-				if slices.Contains(secondDegreePositions, players[i].Position) {
-					return 0.5
-				}
-			}
-
-			return 0.0
-		}()
-
-		if score > bestScore {
-			bestScore = score
-			closest = &players[i]
-		}
-	}
-
-	return closest
-}
-
-func (s *SimulationState) opponentNearestTo(attackerPos models.PlayerPosition, opponents []models.Player) *models.Player {
-	var (
-		bestScore float64
-		closest   *models.Player
-	)
-
-	for i := range opponents {
-		score := func() float64 {
-			adjacentPositions, ok := models.OpponentAdjacents[attackerPos]
-			if !ok {
-				return 0.0
-			}
-
-			if slices.Contains(adjacentPositions, opponents[i].Position) {
-				return 0.75
-			}
-
-			for _, adjPos := range adjacentPositions {
-				secondDegreePositions, ok := models.OpponentAdjacents[adjPos]
-				if !ok {
-					continue
-				}
-				if slices.Contains(secondDegreePositions, opponents[i].Position) {
-					return 0.5
-				}
-			}
-
-			return 0.0
-		}()
-
-		if score > bestScore {
-			bestScore = score
-			closest = &opponents[i]
-		}
-	}
-
-	return closest
-}
-
-func (s *SimulationState) evaluateHold(player models.Player) bool {
+func (s *SimulationState) evaluateHold(team models.Team, player models.Player) bool {
 	if player.Position == models.Goalkeeper {
 		return true
 	}
@@ -749,40 +975,41 @@ func (s *SimulationState) evaluateHold(player models.Player) bool {
 		float64(vision)*visionWeight
 
 	successChance /= 100.0
+	successChance *= s.numbersFactor(team)
+	successChance *= s.condition(team, player)
+	successChance *= s.pressure(team, player)
 
 	return s.Simulation.RandomFloat() < successChance
 }
 
-func (s *SimulationState) evaluateShot(team models.Team, player models.Player) bool {
+// finishing is how well a player strikes the ball right now, on the same
+// scale as averageFinishing. Where they're shooting from and the keeper are
+// already in the shot's xG.
+func (s *SimulationState) finishing(team models.Team, player models.Player) float64 {
 	power := float64(player.Technical.Shooting.Power)
 	finishing := float64(player.Technical.Shooting.Finishing)
 	curve := float64(player.Technical.Shooting.Curve)
-	composure := float64(player.Composure)
+	composure := s.composure(team, player)
 
 	shotScore := power*0.3 + finishing*0.3 + curve*0.2 + composure*0.2
+	shotScore *= s.condition(team, player)
+	shotScore *= s.training(team, models.Shooting)
 
-	if shotScore < 0 {
-		shotScore = 0
-	} else if shotScore > 100 {
-		shotScore = 100
-	}
-
-	opponentTeam := s.Simulation.opposingTeam(team)
-	opponentKeeper := opponentTeam.SearchPlayers(models.PlayerSearchOptions{
-		Positions:  []models.PlayerPosition{models.Goalkeeper},
-		Exclusions: map[models.PlayerNumber]string{player.Number: player.Initials()},
-	})
-	goalkeeperFactor := float64(opponentKeeper.Technical.Goalkeeping.Reflexes)*0.4 +
-		float64(opponentKeeper.Technical.Goalkeeping.Positioning)*0.4 +
-		float64(opponentKeeper.Technical.Goalkeeping.Reactions)*0.2
-	goalkeeperDifficulty := helpers.Sigmoid((goalkeeperFactor - 50) / 10)
+	return shotScore
+}
 
-	shotScore *= 1 - goalkeeperDifficulty
+// evaluateOnTarget is whether a shot that didn't go in at least made the
+// keeper work, rather than going wide or over. It gets harder from further out.
+func (s *SimulationState) evaluateOnTarget(team models.Team, player models.Player) bool {
+	finishing := float64(player.Technical.Shooting.Finishing)
+	curve := float64(player.Technical.Shooting.Curve)
+	composure := s.composure(team, player)
 
-	normalized := (shotScore - 50) / 10
-	probability := helpers.Sigmoid(normalized)
+	accuracy := (finishing*0.5 + curve*0.2 + composure*0.3) / 100.0
+	accuracy *= s.condition(team, player)
+	accuracy *= max(0.4, 1-shotDropOff*max(0, s.shotDistance(team, player)-closeRange))
 
-	return s.Simulation.RandomFloat() < probability
+	return s.Simulation.RandomFloat() < min(0.9, max(0.2, accuracy))
 }
 
 func (s *SimulationState) makeDecision(event Event) Decision {
@@ -796,11 +1023,12 @@ func (s *SimulationState) makeDecision(event Event) Decision {
 		float64(player.TacticalIntelligence.Vision.Shooting)*0.5
 	crossing := float64(player.Technical.Passing.Cross)
 	position := player.Position
+	profile := s.decisionProfile(event.Team, *player)
 
 	rng := s.Simulation.RandomFloat()
 
 	// shooting — more likely if forward or attacking midfielder
-	if helpers.IsAttacker(position) && shooting > 70 && rng < shooting/200.0 {
+	if helpers.IsAttacker(position) && shooting > 70 && rng < shooting/200.0*profile.Shooting {
 		return DecisionShoot
 	}
 
@@ -814,28 +1042,43 @@ func (s *SimulationState) makeDecision(event Event) Decision {
 			involvedInRecentEvents = true
 		}
 	}
-	if (helpers.IsAttacker(position) || helpers.IsWinger(position)) && involvedInRecentEvents {
+	if (helpers.IsAttacker(position) || helpers.IsWinger(position)) && involvedInRecentEvents &&
+		s.Simulation.RandomFloat() < shooting/200.0*profile.Shooting {
 		return DecisionShoot
 	}
 
+	// keeping the ball — for teams who want to hold on to it
+	if s.Simulation.RandomFloat() < tacticHoldUp[event.Team.Strategy.Tactic] {
+		return NoDecision
+	}
+
 	// crossing — more likely for wingers and wide backs
-	if helpers.IsWinger(position) && crossing > 60 && rng < crossing/200.0 {
+	if helpers.IsWinger(position) && crossing > 60 && rng < crossing/200.0*profile.Crossing {
 		return DecisionCross
 	}
 
+	// through balls — for players who can pick out a run in behind
+	throughBall := float64(player.Technical.Passing.ThroughBall)*0.5 +
+		float64(player.TacticalIntelligence.Vision.Passing)*0.5
+	if !slices.Contains(models.Defenders, position) && throughBall > 60 && rng < throughBall/400.0*profile.ThroughBalls {
+		return DecisionThroughBall
+	}
+
 	// dribbling — influenced by agility and dribbling
 	agility := float64(player.Fitness.Agility)
 	dribbleChance := (dribbling*0.6 + agility*0.4) / 100.0
-	if rng < dribbleChance*0.6 {
+	if rng < dribbleChance*0.6*profile.Dribbling {
 		return DecisionDribble
 	}
 
 	// passing — fallback with weighted short/long
-	return s.decidePassType(*player)
+	return s.decidePassType(event.Team, *player)
 }
 
-func (s *SimulationState) decidePassType(player models.Player) Decision {
-	longPassProbability := float64(player.TacticalIntelligence.Vision.Passing) * 0.8 // up to 80% chance
+func (s *SimulationState) decidePassType(team models.Team, player models.Player) Decision {
+	longPassProbability := float64(player.TacticalIntelligence.Vision.Passing) / 100.0 * 0.8 // up to 80% chance
+	longPassProbability *= s.longPassing(team)
+	longPassProbability *= s.decisionProfile(team, player).LongPassing
 
 	if s.Simulation.RandomFloat() < longPassProbability {
 		return DecisionLongPass
@@ -0,0 +1,46 @@
+package simulation_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/notoriousbfg/football-game/scenarios"
+	"github.com/notoriousbfg/football-game/simulation"
+)
+
+func TestSameSeedSameMatch(t *testing.T) {
+	play := func() []byte {
+		sim := simulation.CreateSimulation(scenarios.HomeTeam(), scenarios.AwayTeam(),
+			simulation.WithSeed(7), simulation.WithSinks())
+		sim.Run()
+		var log bytes.Buffer
+		if err := simulation.WriteEventLog(&log, sim.State.Events); err != nil {
+			t.Fatal(err)
+		}
+		return log.Bytes()
+	}
+
+	first, second := play(), play()
+	if !bytes.Equal(first, second) {
+		t.Fatal("two matches played from the same seed produced different event logs")
+	}
+}
+
+func TestInjectedRandomSourceIsNotSeeded(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		opts   []simulation.Option
+		seeded bool
+	}{
+		{"seed 0", []simulation.Option{simulation.WithSeed(0)}, true},
+		{"random source", []simulation.Option{simulation.WithRandomFloat(func() float64 { return 0.5 })}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sim := simulation.CreateSimulation(scenarios.HomeTeam(), scenarios.AwayTeam(),
+				append(tc.opts, simulation.WithSinks())...)
+			if sim.Seeded != tc.seeded {
+				t.Errorf("Seeded = %v, want %v", sim.Seeded, tc.seeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package simulation
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// EventSink receives every event as it is handled, e.g. to print commentary
+// or keep a record of the match.
+type EventSink interface {
+	Receive(s *SimulationState, e Event)
+}
+
+// PitchSink is implemented by sinks that also want the line-ups drawn before
+// kick-off.
+type PitchSink interface {
+	DrawPitch(p *Pitch)
+}
+
+// WriterSink writes a line of commentary per event to W.
+type WriterSink struct {
+	W io.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{W: w}
+}
+
+// NewStdoutSink prints commentary to the terminal.
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink(os.Stdout)
+}
+
+func (w *WriterSink) Receive(s *SimulationState, e Event) {
+	if line := Commentary(s, e); line != "" {
+		fmt.Fprintln(w.W, line)
+	}
+}
+
+func (w *WriterSink) DrawPitch(p *Pitch) {
+	p.Draw(w.W)
+}
+
+// MemorySink keeps every event, and its commentary, in memory.
+type MemorySink struct {
+	Events     []Event
+	Commentary []string
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (m *MemorySink) Receive(s *SimulationState, e Event) {
+	m.Events = append(m.Events, e)
+	if line := Commentary(s, e); line != "" {
+		m.Commentary = append(m.Commentary, line)
+	}
+}
+
+// Commentary describes an event as a line of match commentary, or returns an
+// empty string if there is nothing worth saying about it.
+func Commentary(s *SimulationState, e Event) string {
+	switch e.Type {
+	case ETPass:
+		if throughBall, _ := e.EventMeta["through_ball"].(bool); throughBall {
+			return fmt.Sprintf("(%s) %s threads a through ball to %s", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+		}
+		return fmt.Sprintf("(%s) %s passes to %s", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+	case ETPenalty:
+		return fmt.Sprintf("(%s) Penalty to %s! %s steps up to take it", s.Timestamp(), e.Team.Name, e.FinishingPlayer.Name)
+	case ETGoal:
+		if penalty, _ := e.EventMeta["penalty"].(bool); penalty {
+			return fmt.Sprintf("(%s) %s sends the keeper the wrong way and scores the penalty!", s.Timestamp(), e.FinishingPlayer.Name)
+		}
+		if e.EventMeta["shot_type"] == ShotHeader {
+			return fmt.Sprintf("(%s) %s heads it in from %s's delivery!", s.Timestamp(), e.FinishingPlayer.Name, e.StartingPlayer.Name)
+		}
+		if freeKick, _ := e.EventMeta["free_kick"].(bool); freeKick {
+			return fmt.Sprintf("(%s) %s curls the free kick into the net!", s.Timestamp(), e.FinishingPlayer.Name)
+		}
+		return fmt.Sprintf("(%s) %s shoots and scores!", s.Timestamp(), e.FinishingPlayer.Name)
+	case ETMiss:
+		if penalty, _ := e.EventMeta["penalty"].(bool); penalty {
+			return fmt.Sprintf("(%s) %s puts the penalty wide!", s.Timestamp(), e.StartingPlayer.Name)
+		}
+		if e.EventMeta["shot_type"] == ShotHeader {
+			return fmt.Sprintf("(%s) %s's header goes wide", s.Timestamp(), e.FinishingPlayer.Name)
+		}
+		return fmt.Sprintf("(%s) %s's shot goes wide", s.Timestamp(), e.StartingPlayer.Name)
+	case ETReset:
+		return fmt.Sprintf("(%s) The game restarts after the goal", s.Timestamp())
+	case ETCross:
+		return fmt.Sprintf("(%s) %s crosses to %s", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+	case ETDribble:
+		return fmt.Sprintf("(%s) %s is dribbling with the ball", s.Timestamp(), e.StartingPlayer.Name)
+	case ETInterception:
+		if blocked, _ := e.EventMeta["blocked"].(bool); blocked {
+			return fmt.Sprintf("(%s) %s's free kick is blocked by %s in the wall", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+		}
+		if cleared, _ := e.EventMeta["cleared"].(bool); cleared {
+			return fmt.Sprintf("(%s) %s's delivery is headed clear by %s", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+		}
+		return fmt.Sprintf("(%s) %s loses the ball to %s", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+	case ETCorner:
+		switch e.EventMeta["from"] {
+		case "save":
+			return fmt.Sprintf("(%s) The keeper pushes it behind. Corner to %s, %s goes over to take it", s.Timestamp(), e.Team.Name, e.StartingPlayer.Name)
+		case "miss":
+			return fmt.Sprintf("(%s) It takes a deflection on the way out. Corner to %s, %s goes over to take it", s.Timestamp(), e.Team.Name, e.StartingPlayer.Name)
+		case "cross":
+			return fmt.Sprintf("(%s) The cross is blocked behind. Corner to %s, %s goes over to take it", s.Timestamp(), e.Team.Name, e.StartingPlayer.Name)
+		default:
+			return fmt.Sprintf("(%s) It's headed behind. Corner to %s, %s goes over to take it", s.Timestamp(), e.Team.Name, e.StartingPlayer.Name)
+		}
+	case ETGoalKick:
+		return fmt.Sprintf("(%s) Goal kick to %s", s.Timestamp(), e.Team.Name)
+	case ETThrowIn:
+		return fmt.Sprintf("(%s) The ball goes out of play. Throw-in to %s, taken by %s", s.Timestamp(), e.Team.Name, e.StartingPlayer.Name)
+	case ETPossession:
+		return fmt.Sprintf("(%s) %s has the ball", s.Timestamp(), e.FinishingPlayer.Name)
+	case ETFoul:
+		return fmt.Sprintf("(%s) %s is fouled by %s", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+	case ETFreeKickOnGoal:
+		return fmt.Sprintf("(%s) %s stands over a free kick within range of goal", s.Timestamp(), e.StartingPlayer.Name)
+	case ETOffside:
+		return fmt.Sprintf("(%s) %s plays it through for %s, but the flag is up for offside", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+	case ETFreeKickDefensiveHalf:
+		if offside, _ := e.EventMeta["offside"].(bool); offside {
+			return fmt.Sprintf("(%s) %s takes the free kick for offside", s.Timestamp(), e.StartingPlayer.Name)
+		}
+		return fmt.Sprintf("(%s) %s takes a free kick in their own half", s.Timestamp(), e.StartingPlayer.Name)
+	case ETYellowCard:
+		return fmt.Sprintf("(%s) %s is given a yellow card for a foul", s.Timestamp(), e.FinishingPlayer.Name)
+	case ETRedCard:
+		if secondYellow, _ := e.EventMeta["second_yellow"].(bool); secondYellow {
+			return fmt.Sprintf("(%s) %s is shown a second yellow card and is sent off", s.Timestamp(), e.FinishingPlayer.Name)
+		}
+		return fmt.Sprintf("(%s) %s is shown a red card for a bad foul", s.Timestamp(), e.FinishingPlayer.Name)
+	case ETSave:
+		if penalty, _ := e.EventMeta["penalty"].(bool); penalty {
+			return fmt.Sprintf("(%s) %s's penalty is saved by %s!", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+		}
+		return fmt.Sprintf("(%s) %s took a shot but it was saved by %s", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name)
+	case ETInjury:
+		if e.EventMeta["severity"] == InjuryKnock {
+			return fmt.Sprintf("(%s) %s goes down injured, but gets up and carries on", s.Timestamp(), e.FinishingPlayer.Name)
+		}
+		return fmt.Sprintf("(%s) %s goes down injured and will need to come off", s.Timestamp(), e.FinishingPlayer.Name)
+	case ETSubstitution:
+		return fmt.Sprintf("(%s) Substitution for %s: %s comes on for %s", s.Timestamp(), e.Team.Name, e.FinishingPlayer.Name, e.StartingPlayer.Name)
+	case ETEndOfFirstHalf:
+		return fmt.Sprintf("(%s) %d minutes extra time added in the first half", s.Timestamp(), int(s.FirstHalfExtraTime.Minutes()))
+	case ETEndOfFirstHalfExtraTime:
+		return fmt.Sprintf("(%s) The whistle blows for the end of the first half", s.Timestamp())
+	case ETEndOfSecondHalf:
+		return fmt.Sprintf("(%s) %d minutes extra time added in the second half", s.Timestamp(), int(s.SecondHalfExtraTime.Minutes()))
+	case ETEndOfSecondHalfExtraTime:
+		if s.Simulation.Rules.Knockout && s.HomeScore == s.AwayScore {
+			return fmt.Sprintf("(%s) The whistle blows and it's level, so we're going to extra time", s.Timestamp())
+		}
+		return fmt.Sprintf("(%s) The full time whistle blows", s.Timestamp())
+	case ETEndOfExtraTimeFirstHalf:
+		return fmt.Sprintf("(%s) The whistle blows for the end of the first half of extra time", s.Timestamp())
+	case ETEndOfExtraTimeSecondHalf:
+		return fmt.Sprintf("(%s) The whistle blows for the end of extra time", s.Timestamp())
+	case ETPenaltyShootout:
+		return fmt.Sprintf("(%s) Still level after extra time, so it goes to penalties", s.Timestamp())
+	case ETShootoutKick:
+		score := fmt.Sprintf("%s %d - %d %s", s.Simulation.Match.H.Name, s.HomeShootoutScore, s.AwayShootoutScore, s.Simulation.Match.A.Name)
+		switch e.EventMeta["result"] {
+		case "scored":
+			return fmt.Sprintf("(%s) %s scores their penalty (%s)", s.Timestamp(), e.StartingPlayer.Name, score)
+		case "saved":
+			return fmt.Sprintf("(%s) %s's penalty is saved by %s (%s)", s.Timestamp(), e.StartingPlayer.Name, e.FinishingPlayer.Name, score)
+		default:
+			return fmt.Sprintf("(%s) %s misses the target (%s)", s.Timestamp(), e.StartingPlayer.Name, score)
+		}
+		// case ETRestart:
+		// 	// Assuming this special case doesn't need a full Event object
+		// 	return fmt.Sprintf("(%s) The game restarts.", s.Timestamp())
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+package simulation
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// managers start thinking about changes after this point in the match
+const substitutionWindow = 55 * time.Minute
+
+// chance a manager makes a change at any given stoppage once in the window
+const substitutionChance = 0.15
+
+type Substitution struct {
+	Off  models.Player
+	On   models.Player
+	Time time.Duration
+}
+
+func (s *SimulationState) SubstitutionsLeft(team models.Team) int {
+	return s.Simulation.Rules.MaxSubstitutions - len(s.Substitutions[team.Name])
+}
+
+// Substitute brings a player on from the bench in place of one on the pitch.
+// It can only happen while the ball is dead, i.e. from a trigger or before
+// the match starts.
+func (s *SimulationState) Substitute(team models.Team, off, on models.PlayerNumber) error {
+	lineup := s.Simulation.team(team)
+	if s.SubstitutionsLeft(*lineup) <= 0 {
+		return fmt.Errorf("%s have no substitutions left", lineup.Name)
+	}
+
+	offIndex := slices.IndexFunc(lineup.Players, func(p models.Player) bool { return p.Number == off })
+	if offIndex < 0 {
+		return fmt.Errorf("%s have no player %d on the pitch", lineup.Name, off)
+	}
+	onIndex := slices.IndexFunc(lineup.Bench, func(p models.Player) bool { return p.Number == on })
+	if onIndex < 0 {
+		return fmt.Errorf("%s have no player %d on the bench", lineup.Name, on)
+	}
+
+	offPlayer := lineup.Players[offIndex]
+	// the substitute slots into the role of the player they're replacing,
+	// except that a reserve keeper only ever goes in goal
+	onPlayer := lineup.Bench[onIndex]
+	if onPlayer.Natural() != models.Goalkeeper {
+		onPlayer = onPlayer.PlayingAt(offPlayer.Position)
+	}
+	s.handle(Event{
+		Type:            ETSubstitution,
+		Team:            *lineup,
+		StartingPlayer:  &offPlayer,
+		FinishingPlayer: &onPlayer,
+	})
+	return nil
+}
+
+// swap replaces the lineup's slices rather than writing into them, since
+// earlier events still hold copies of the team.
+func (s *SimulationState) swap(e Event) {
+	lineup := s.Simulation.team(e.Team)
+	off, on := *e.StartingPlayer, *e.FinishingPlayer
+
+	lineup.Players = slices.Clone(lineup.Players)
+	lineup.Players[slices.IndexFunc(lineup.Players, func(p models.Player) bool { return p.Number == off.Number })] = on
+	lineup.Bench = slices.DeleteFunc(slices.Clone(lineup.Bench), func(p models.Player) bool { return p.Number == on.Number })
+
+	s.Substitutions[lineup.Name] = append(s.Substitutions[lineup.Name], Substitution{
+		Off:  off,
+		On:   on,
+		Time: s.elapsed(),
+	})
+}
+
+// considerSubstitutions gives each manager the chance to make a change while
+// the ball is dead.
+func (s *SimulationState) considerSubstitutions() {
+	s.replaceInjured()
+	if !s.SecondHalfStarted || s.elapsed() < substitutionWindow {
+		return
+	}
+
+	for _, lineup := range []*models.Team{&s.Simulation.Match.H, &s.Simulation.Match.A} {
+		if s.SubstitutionsLeft(*lineup) <= 0 || len(lineup.Bench) == 0 {
+			continue
+		}
+		if s.Simulation.RandomFloat() >= substitutionChance {
+			continue
+		}
+
+		off, ok := s.tiredestPlayer(*lineup)
+		if !ok {
+			continue
+		}
+		on, ok := models.BestReplacement(off.Position, lineup.Bench)
+		if !ok {
+			continue
+		}
+		if err := s.Substitute(*lineup, off.Number, on.Number); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// tiredestPlayer is the outfield starter with the least energy left. Players
+// who have already come on are left alone.
+func (s *SimulationState) tiredestPlayer(team models.Team) (models.Player, bool) {
+	var (
+		tiredest models.Player
+		found    bool
+	)
+	for _, player := range team.Players {
+		if player.Position == models.Goalkeeper || s.cameOn(team, player) {
+			continue
+		}
+		if !found || s.energy(team, player) < s.energy(team, tiredest) {
+			tiredest = player
+			found = true
+		}
+	}
+	return tiredest, found
+}
+
+func (s *SimulationState) cameOn(team models.Team, player models.Player) bool {
+	return slices.ContainsFunc(s.Substitutions[team.Name], func(sub Substitution) bool {
+		return sub.On.Number == player.Number
+	})
+}
@@ -0,0 +1,89 @@
+package simulation
+
+import (
+	"slices"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// how easily opponents keep the ball in their own half against each tactic
+var tacticPressure = map[models.Tactic]float64{
+	models.TacticCounter:   1.0,
+	models.TacticPressing:  0.85,
+	models.TacticDefensive: 1.05,
+	models.TacticHolding:   1.0,
+}
+
+// how quickly players tire under each tactic
+var tacticWorkRate = map[models.Tactic]float64{
+	models.TacticCounter:   1.0,
+	models.TacticPressing:  1.3,
+	models.TacticDefensive: 0.9,
+	models.TacticHolding:   0.85,
+}
+
+// how much more (or less) often each tactic goes long
+var tacticLongPassing = map[models.Tactic]float64{
+	models.TacticCounter:   1.0,
+	models.TacticPressing:  0.9,
+	models.TacticDefensive: 1.2,
+	models.TacticHolding:   0.5,
+}
+
+// chance of keeping the ball rather than moving it on
+var tacticHoldUp = map[models.Tactic]float64{
+	models.TacticCounter:   0.0,
+	models.TacticPressing:  0.0,
+	models.TacticDefensive: 0.05,
+	models.TacticHolding:   0.2,
+}
+
+// counter-attacking teams go long this much more often straight after
+// winning the ball
+const counterAttackLongPassing = 2.0
+
+// an opponent closer than closingDownRange takes up to closingDownPenalty off
+// a player's chance of keeping the ball
+const (
+	closingDownRange   = 10.0 // metres
+	closingDownPenalty = 0.15
+)
+
+// pressure scales a player's chance of keeping the ball by how closely the
+// opposition is on them: a man-marker follows them everywhere, the nearest
+// opponent closes them down, and pressing only bites in the player's own
+// half. Teams who train on defending are harder to keep the ball against.
+func (s *SimulationState) pressure(team models.Team, player models.Player) float64 {
+	opposingTeam := s.Simulation.opposingTeam(team)
+	at := s.location(team, player)
+
+	pressure := 1.0
+	if s.marker(team, player) != nil {
+		pressure *= markedPenalty
+	}
+	if s.ownHalf(team, at) {
+		pressure *= tacticPressure[opposingTeam.Strategy.Tactic]
+	}
+	if nearest := s.nearestTo(opposingTeam, at, opposingTeam.Players); nearest != nil {
+		distance := at.Distance(s.location(opposingTeam, *nearest))
+		pressure *= 1 - closingDownPenalty*max(0, 1-distance/closingDownRange)
+	}
+	return pressure / s.training(opposingTeam, models.Defense)
+}
+
+// longPassing scales a team's preference for going long, which for counter
+// attacking teams depends on whether they've just won the ball back.
+func (s *SimulationState) longPassing(team models.Team) float64 {
+	tactic := team.Strategy.Tactic
+	if tactic == models.TacticCounter && s.justWonBall(team) {
+		return counterAttackLongPassing
+	}
+	return tacticLongPassing[tactic]
+}
+
+func (s *SimulationState) justWonBall(team models.Team) bool {
+	recent := s.Events[max(0, len(s.Events)-3):]
+	return slices.ContainsFunc(recent, func(e Event) bool {
+		return e.Type == ETInterception && e.Team.Name == team.Name
+	})
+}
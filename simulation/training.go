@@ -0,0 +1,122 @@
+package simulation
+
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// how much a team's training focus helps with the matching part of its game
+const trainingBoost = 1.05
+
+// chance an average attribute improves by a point in a week of training; it
+// gets harder the closer the attribute is to 100
+const weeklyImprovement = 0.25
+
+// training scales a team's chances in whatever its training has focused on.
+func (s *SimulationState) training(team models.Team, focus models.TrainingFocus) float64 {
+	if s.Simulation.lineup(team).Training.Focus == focus {
+		return trainingBoost
+	}
+	return 1
+}
+
+type trainedAttribute struct {
+	path  string // as it appears in team files
+	field func(*models.TechnicalSkill) *int
+}
+
+// trainedAttributes are the technical skills each training focus improves.
+var trainedAttributes = map[models.TrainingFocus][]trainedAttribute{
+	models.Passing: {
+		{"technical.passing.short_pass", func(t *models.TechnicalSkill) *int { return &t.Passing.ShortPass }},
+		{"technical.passing.long_pass", func(t *models.TechnicalSkill) *int { return &t.Passing.LongPass }},
+		{"technical.passing.cross", func(t *models.TechnicalSkill) *int { return &t.Passing.Cross }},
+		{"technical.passing.lob", func(t *models.TechnicalSkill) *int { return &t.Passing.Lob }},
+		{"technical.passing.through_ball", func(t *models.TechnicalSkill) *int { return &t.Passing.ThroughBall }},
+		{"technical.passing.chip", func(t *models.TechnicalSkill) *int { return &t.Passing.Chip }},
+	},
+	models.Defense: {
+		{"technical.defending.jumping", func(t *models.TechnicalSkill) *int { return &t.Defending.Jumping }},
+		{"technical.defending.interceptions", func(t *models.TechnicalSkill) *int { return &t.Defending.Interceptions }},
+		{"technical.defending.heading.accuracy", func(t *models.TechnicalSkill) *int { return &t.Defending.Heading.Accuracy }},
+		{"technical.defending.heading.power", func(t *models.TechnicalSkill) *int { return &t.Defending.Heading.Power }},
+		{"technical.defending.blocking", func(t *models.TechnicalSkill) *int { return &t.Defending.Blocking }},
+	},
+	models.Shooting: {
+		{"technical.shooting.power", func(t *models.TechnicalSkill) *int { return &t.Shooting.Power }},
+		{"technical.shooting.curve", func(t *models.TechnicalSkill) *int { return &t.Shooting.Curve }},
+		{"technical.shooting.finishing", func(t *models.TechnicalSkill) *int { return &t.Shooting.Finishing }},
+		{"technical.shooting.spin", func(t *models.TechnicalSkill) *int { return &t.Shooting.Spin }},
+	},
+	models.Penalties: {
+		{"technical.penalties", func(t *models.TechnicalSkill) *int { return &t.Penalties }},
+	},
+	models.SetPieces: {
+		{"technical.free_kicks", func(t *models.TechnicalSkill) *int { return &t.FreeKicks }},
+		{"technical.passing.cross", func(t *models.TechnicalSkill) *int { return &t.Passing.Cross }},
+		{"technical.shooting.curve", func(t *models.TechnicalSkill) *int { return &t.Shooting.Curve }},
+	},
+}
+
+type AttributeChange struct {
+	Attribute string `json:"attribute"`
+	Before    int    `json:"before"`
+	After     int    `json:"after"`
+}
+
+type PlayerDevelopment struct {
+	Name    string              `json:"name"`
+	Number  models.PlayerNumber `json:"number"`
+	Changes []AttributeChange   `json:"changes"`
+}
+
+type TrainingReport struct {
+	Team    string               `json:"team"`
+	Focus   models.TrainingFocus `json:"focus"`
+	Weeks   int                  `json:"weeks"`
+	Seed    int64                `json:"seed"`
+	Players []PlayerDevelopment  `json:"players"`
+}
+
+// Develop puts a whole squad through weeks of training on the team's focus.
+// Each week every attribute the focus covers has a chance of going up a
+// point, which is better for adaptable players and for attributes with more
+// room to improve. It returns the developed team, leaving the one passed in
+// alone, and a report of how every trained attribute changed.
+func Develop(team models.Team, weeks int, seed int64) (models.Team, TrainingReport) {
+	randGen := rand.New(rand.NewSource(seed))
+	attributes := trainedAttributes[team.Training.Focus]
+
+	team.Players = slices.Clone(team.Players)
+	team.Bench = slices.Clone(team.Bench)
+
+	report := TrainingReport{
+		Team:  team.Name,
+		Focus: team.Training.Focus,
+		Weeks: weeks,
+		Seed:  seed,
+	}
+	for _, squad := range [][]models.Player{team.Players, team.Bench} {
+		for i := range squad {
+			player := &squad[i]
+			development := PlayerDevelopment{Name: player.Name, Number: player.Number}
+			for _, attribute := range attributes {
+				value := attribute.field(&player.Technical)
+				change := AttributeChange{Attribute: attribute.path, Before: *value}
+				for range weeks {
+					chance := weeklyImprovement * (1 - float64(*value)/100) * (0.5 + float64(player.Adaptability)/100)
+					if randGen.Float64() < chance {
+						*value = min(100, *value+1)
+					}
+				}
+				change.After = *value
+				development.Changes = append(development.Changes, change)
+			}
+			report.Players = append(report.Players, development)
+		}
+	}
+
+	return team, report
+}
@@ -0,0 +1,170 @@
+package simulation
+
+import (
+	"math"
+	"strings"
+
+	"github.com/notoriousbfg/football-game/models"
+)
+
+// xG falls away exponentially with distance from goal: xgScale right on the
+// line, and a factor of e less every xgDecay metres
+const (
+	xgScale = 0.9
+	xgDecay = 7.0
+	minXG   = 0.01
+	maxXG   = 0.95
+)
+
+// the xG of a penalty, whoever takes it
+const penaltyXG = 0.76
+
+// shots from further out than this are long range
+const longRange = 20.0
+
+// a finisher this good scores as often as xG says
+const averageFinishing = 70.0
+
+//go:generate stringer -type=ShotType -output shot_type_string.go
+type ShotType int
+
+const (
+	ShotStandard ShotType = iota
+	ShotHeader
+	ShotVolley
+	ShotOneOnOne
+	ShotLongRange // distance already counts against these
+	ShotFreeKick
+	ShotPenalty
+)
+
+func (t ShotType) MarshalText() ([]byte, error) {
+	return []byte(strings.TrimPrefix(t.String(), "Shot")), nil
+}
+
+//go:generate stringer -type=AssistType -output assist_type_string.go
+type AssistType int
+
+const (
+	AssistNone AssistType = iota // nobody set it up, e.g. after winning the ball back
+	AssistPass
+	AssistThroughBall
+	AssistCross
+	AssistSetPiece // a corner or a free kick put into the box
+	AssistSolo     // beat their man first
+)
+
+func (a AssistType) MarshalText() ([]byte, error) {
+	return []byte(strings.TrimPrefix(a.String(), "Assist")), nil
+}
+
+// how each kind of shot compares to a shot with the feet from the same spot
+var shotTypeXG = map[ShotType]float64{
+	ShotStandard:  1.0,
+	ShotHeader:    0.6,
+	ShotVolley:    0.8,
+	ShotOneOnOne:  1.6,
+	ShotLongRange: 1.0,
+	ShotFreeKick:  1.0,
+}
+
+// how the way the chance was made changes it
+var assistXG = map[AssistType]float64{
+	AssistNone:        0.9,
+	AssistPass:        1.0,
+	AssistThroughBall: 1.2,
+	AssistCross:       1.0,
+	AssistSetPiece:    0.9,
+	AssistSolo:        1.1,
+}
+
+// shotContext works out what kind of shot a player is having from how the
+// ball came to them and where they are.
+func (s *SimulationState) shotContext(team models.Team, player models.Player) (ShotType, AssistType) {
+	last := s.LastEvent()
+	assist := AssistNone
+	switch last.Type {
+	case ETCross:
+		assist = AssistCross
+	case ETPass:
+		assist = AssistPass
+		if throughBall, _ := last.EventMeta["through_ball"].(bool); throughBall {
+			assist = AssistThroughBall
+		}
+	case ETDribble:
+		assist = AssistSolo
+	}
+
+	switch {
+	case assist == AssistThroughBall:
+		// clean through, wherever they ran on to it
+		return ShotOneOnOne, assist
+	case s.shotDistance(team, player) > longRange:
+		return ShotLongRange, assist
+	case last.Type == ETCross:
+		// whichever they're better at
+		if aerialSkill(player) >= float64(player.Technical.Shooting.Finishing) {
+			return ShotHeader, assist
+		}
+		return ShotVolley, assist
+	}
+	return ShotStandard, assist
+}
+
+// expectedGoals is how often a shot like this goes in, whoever takes it:
+// it's down to how far out it is, the kind of shot, how the chance was made,
+// how closely the shooter is marked and how good the keeper is.
+func (s *SimulationState) expectedGoals(team models.Team, player models.Player, shot ShotType, assist AssistType) float64 {
+	if shot == ShotPenalty {
+		return penaltyXG
+	}
+
+	xg := xgScale * math.Exp(-s.shotDistance(team, player)/xgDecay)
+	xg *= shotTypeXG[shot] * assistXG[assist]
+	if shot != ShotFreeKick {
+		xg *= s.pressure(team, player)
+	}
+
+	opposingTeam := s.Simulation.opposingTeam(team)
+	keeper := opposingTeam.SearchPlayers(models.PlayerSearchOptions{
+		Positions: []models.PlayerPosition{models.Goalkeeper},
+	})
+	xg *= 1.2 - s.keeping(opposingTeam, keeper)/250
+
+	return min(maxXG, max(minXG, xg))
+}
+
+// shotMeta describes a shot for its event.
+func (s *SimulationState) shotMeta(team models.Team, player models.Player, shot ShotType, assist AssistType) EventMeta {
+	return EventMeta{
+		"xg":        rounded(s.expectedGoals(team, player, shot, assist)),
+		"shot_type": shot,
+		"assist":    assist,
+		"distance":  math.Round(s.shotDistance(team, player)*10) / 10,
+	}
+}
+
+// scores is whether a shot goes in: as often as its xG says for an average
+// finisher, and more or less often for better or worse ones.
+func (s *SimulationState) scores(meta EventMeta, finishing float64) bool {
+	xg, _ := meta["xg"].(float64)
+	return s.Simulation.RandomFloat() < min(maxXG, xg*finishing/averageFinishing)
+}
+
+// tallyXG adds a shot's xG to the side that took it.
+func (s *SimulationState) tallyXG(e Event) {
+	xg, ok := e.EventMeta["xg"].(float64)
+	if !ok {
+		return
+	}
+	team := e.Team
+	if e.Type != ETGoal && e.Type != ETMiss {
+		// saves and blocks belong to the defending side
+		team = s.Simulation.opposingTeam(team)
+	}
+	if s.isHome(team) {
+		s.HomeXG += xg
+	} else {
+		s.AwayXG += xg
+	}
+}